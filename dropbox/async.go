@@ -0,0 +1,125 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// PollPolicy configures how a Client waits for asynchronous jobs, such as
+// batch commits, batch copies, moves and deletes, or URL saves.
+type PollPolicy struct {
+	// The delay before the first status check of a job. It is doubled after
+	// each check.
+	MinInterval time.Duration
+
+	// The maximum delay between two status checks.
+	MaxInterval time.Duration
+}
+
+// DefaultPollPolicy is the poll policy used by new clients.
+var DefaultPollPolicy = PollPolicy{
+	MinInterval: 500 * time.Millisecond,
+	MaxInterval: 10 * time.Second,
+}
+
+// interval returns the delay before the given status check, starting at 1.
+func (p *PollPolicy) interval(check int) time.Duration {
+	d := p.MinInterval << uint(check-1)
+	if d > p.MaxInterval || d <= 0 {
+		d = p.MaxInterval
+	}
+	if d < p.MinInterval {
+		d = p.MinInterval
+	}
+	return d
+}
+
+// AsyncStatus is the launch or check result of an asynchronous job, decoded
+// from JSON. These are unions whose "async_job_id" and "in_progress" tags
+// report the job is still running, while the rest of tags report its
+// completion or failure. For example:
+//
+//	type jobStatus struct {
+//		Tag        string    `json:".tag"`
+//		AsyncJobID string    `json:"async_job_id"`
+//		Failed     *JobError `json:"failed"`
+//	}
+//
+//	func (s *jobStatus) Pending() bool {
+//		return s.Tag == "async_job_id" || s.Tag == "in_progress"
+//	}
+type AsyncStatus interface {
+	// Pending reports whether the job is still running.
+	Pending() bool
+}
+
+// CheckAsyncJob checks the status of the asynchronous job jobID using the
+// check endpoint urlStr, relative to BaseURL, and decodes it in status.
+// Errors are returned as an *APIError holding a *PollError.
+func (c *Client) CheckAsyncJob(ctx context.Context, urlStr, jobID string, status interface{}) (*http.Response, error) {
+	arg := struct {
+		AsyncJobID string `json:"async_job_id"`
+	}{jobID}
+	req, err := c.NewRPCRequest("POST", urlStr, &arg)
+	if err != nil {
+		return nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	return c.do(ctx, (*http.Request)(req), status, new(PollError))
+}
+
+// WaitAsyncJob waits for an asynchronous job to complete or fail. status
+// holds the launch result of the job, and is updated with each check of the
+// job jobID, using the check endpoint urlStr, relative to BaseURL, until it
+// is no longer pending. It then holds the completion or failure of the job.
+// Checks are spaced as configured by policy, or by c.PollPolicy if nil. The
+// response of the last check is returned, or nil if status was not pending.
+// Errors checking the job are returned as an *APIError holding a *PollError.
+func (c *Client) WaitAsyncJob(ctx context.Context, urlStr, jobID string, status AsyncStatus, policy *PollPolicy) (*http.Response, error) {
+	if policy == nil {
+		policy = c.PollPolicy
+	}
+	if policy == nil {
+		policy = &DefaultPollPolicy
+	}
+
+	var resp *http.Response
+	for check := 1; status.Pending(); check++ {
+		if err := sleep(ctx, policy.interval(check)); err != nil {
+			return resp, err
+		}
+		var err error
+		if resp, err = c.CheckAsyncJob(ctx, urlStr, jobID, status); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+// PollError describes why the status of an asynchronous job could not be
+// checked.
+type PollError struct {
+	// One of "invalid_async_job_id", "internal_error" or "other".
+	Tag string `json:".tag"`
+}
+
+var pollErrorUnion = newUnion("other", map[string]interface{}{
+	"invalid_async_job_id": nil,
+	"internal_error":       nil,
+	"other":                nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *PollError) UnmarshalJSON(b []byte) error {
+	return pollErrorUnion.unmarshal(b, e)
+}
+
+func (e *PollError) Error() string {
+	return "poll failed: " + e.Tag
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type testAsyncStatus struct {
+	Tag   string `json:".tag"`
+	Value string `json:"value"`
+}
+
+func (s *testAsyncStatus) Pending() bool {
+	return s.Tag == "async_job_id" || s.Tag == "in_progress"
+}
+
+func TestPollPolicy_interval(t *testing.T) {
+	p := PollPolicy{MinInterval: time.Second, MaxInterval: 5 * time.Second}
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		check := i + 1
+		if got := p.interval(check); got != want {
+			t.Errorf("interval(%d) is %v, want %v", check, got, want)
+		}
+	}
+	if got, want := p.interval(100), 5*time.Second; got != want {
+		t.Errorf("interval(100) is %v, want %v", got, want)
+	}
+}
+
+func TestWaitAsyncJob(t *testing.T) {
+	setup()
+	defer teardown()
+
+	checks := 0
+	mux.HandleFunc("/check", func(w http.ResponseWriter, r *http.Request) {
+		checks++
+		if checks < 3 {
+			fmt.Fprint(w, `{".tag":"in_progress"}`)
+			return
+		}
+		fmt.Fprint(w, `{".tag":"complete","value":"done"}`)
+	})
+
+	status := &testAsyncStatus{Tag: "async_job_id"}
+	if _, err := client.WaitAsyncJob(context.Background(), "check", "job", status, nil); err != nil {
+		t.Fatalf("WaitAsyncJob returned unexpected error: %v", err)
+	}
+	if got, want := checks, 3; got != want {
+		t.Errorf("WaitAsyncJob made %v checks, want %v", got, want)
+	}
+	if got, want := status.Value, "done"; got != want {
+		t.Errorf("WaitAsyncJob status value is %v, want %v", got, want)
+	}
+}
+
+func TestWaitAsyncJob_completed(t *testing.T) {
+	status := &testAsyncStatus{Tag: "complete"}
+	if _, err := NewClient(nil).WaitAsyncJob(context.Background(), "check", "", status, nil); err != nil {
+		t.Errorf("WaitAsyncJob returned unexpected error: %v", err)
+	}
+}
+
+func TestWaitAsyncJob_invalidJobID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/check", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"invalid_async_job_id/..","error":{".tag":"invalid_async_job_id"}}`)
+	})
+
+	status := &testAsyncStatus{Tag: "async_job_id"}
+	_, err := client.WaitAsyncJob(context.Background(), "check", "job", status, nil)
+	var pollErr *PollError
+	if !errors.As(err, &pollErr) || pollErr.Tag != "invalid_async_job_id" {
+		t.Errorf("WaitAsyncJob error is %#v, want an invalid_async_job_id *PollError", err)
+	}
+}
+
+func TestWaitAsyncJob_canceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/check", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{".tag":"in_progress"}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	status := &testAsyncStatus{Tag: "async_job_id"}
+	_, err := client.WaitAsyncJob(ctx, "check", "job", status, &PollPolicy{MinInterval: time.Hour, MaxInterval: time.Hour})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitAsyncJob error is %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"crypto/sha256"
+	"hash"
+)
+
+// ContentHashBlockSize is the size of the blocks hashed by ContentHash.
+const ContentHashBlockSize = 4 << 20
+
+// ContentHash computes the Dropbox content hash of a file, as reported in
+// FileMetadata.ContentHash. The file content is split in blocks of
+// ContentHashBlockSize bytes, each block is hashed with SHA-256, and the
+// concatenation of the block hashes is hashed again with SHA-256. The hex
+// encoding of the sum can be compared with FileMetadata.ContentHash:
+//
+//	h := dropbox.NewContentHash()
+//	io.Copy(h, file)
+//	if hex.EncodeToString(h.Sum(nil)) == meta.ContentHash {
+//		...
+//	}
+type ContentHash struct {
+	sums  []byte    // The hashes of the complete blocks.
+	block hash.Hash // The hash of the current block.
+	n     int       // The size of the current block.
+}
+
+var _ hash.Hash = (*ContentHash)(nil)
+
+// NewContentHash returns a new ContentHash.
+func NewContentHash() *ContentHash {
+	return &ContentHash{block: sha256.New()}
+}
+
+// Write adds more data to the running hash. It never returns an error.
+func (h *ContentHash) Write(p []byte) (int, error) {
+	written := len(p)
+	for len(p) > 0 {
+		n := ContentHashBlockSize - h.n
+		if n > len(p) {
+			n = len(p)
+		}
+		h.block.Write(p[:n])
+		h.n += n
+		p = p[n:]
+		if h.n == ContentHashBlockSize {
+			h.sums = h.block.Sum(h.sums)
+			h.block.Reset()
+			h.n = 0
+		}
+	}
+	return written, nil
+}
+
+// Sum appends the current hash to b and returns the resulting slice. It does
+// not change the underlying hash state.
+func (h *ContentHash) Sum(b []byte) []byte {
+	overall := sha256.New()
+	overall.Write(h.sums)
+	if h.n > 0 {
+		overall.Write(h.block.Sum(nil))
+	}
+	return overall.Sum(b)
+}
+
+// Reset resets the hash to its initial state.
+func (h *ContentHash) Reset() {
+	h.sums = h.sums[:0]
+	h.block.Reset()
+	h.n = 0
+}
+
+// Size returns the number of bytes Sum returns.
+func (h *ContentHash) Size() int {
+	return sha256.Size
+}
+
+// BlockSize returns the block size of the underlying SHA-256 hash.
+func (h *ContentHash) BlockSize() int {
+	return sha256.BlockSize
+}
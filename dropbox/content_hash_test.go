@@ -0,0 +1,68 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+// contentHash computes the content hash of data in a single pass.
+func contentHash(data []byte) string {
+	var sums []byte
+	for len(data) > 0 {
+		n := ContentHashBlockSize
+		if n > len(data) {
+			n = len(data)
+		}
+		sum := sha256.Sum256(data[:n])
+		sums = append(sums, sum[:]...)
+		data = data[n:]
+	}
+	sum := sha256.Sum256(sums)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestContentHash(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), (2*ContentHashBlockSize+1000)/16)
+	for _, size := range []int{0, 1, 1000, ContentHashBlockSize, ContentHashBlockSize + 1, len(data)} {
+		want := contentHash(data[:size])
+
+		h := NewContentHash()
+		for p := data[:size]; len(p) > 0; {
+			n := 333333
+			if n > len(p) {
+				n = len(p)
+			}
+			h.Write(p[:n])
+			p = p[n:]
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != want {
+			t.Errorf("ContentHash of %d bytes is %v, want %v", size, got, want)
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != want {
+			t.Errorf("ContentHash of %d bytes summed twice is %v, want %v", size, got, want)
+		}
+	}
+}
+
+func TestContentHash_empty(t *testing.T) {
+	want := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	if got := hex.EncodeToString(NewContentHash().Sum(nil)); got != want {
+		t.Errorf("ContentHash of no data is %v, want %v", got, want)
+	}
+}
+
+func TestContentHash_reset(t *testing.T) {
+	h := NewContentHash()
+	h.Write(make([]byte, ContentHashBlockSize+10))
+	h.Reset()
+	h.Write([]byte("abc"))
+	if got, want := hex.EncodeToString(h.Sum(nil)), contentHash([]byte("abc")); got != want {
+		t.Errorf("ContentHash after Reset is %v, want %v", got, want)
+	}
+}
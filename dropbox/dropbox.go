@@ -6,27 +6,48 @@ package dropbox
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
 const (
 	libraryVersion    = "0.1"
 	defaultBaseURL    = "https://api.dropbox.com/"
 	defaultContentURL = "https://api-content.dropbox.com/"
+	defaultNotifyURL  = "https://api-notify.dropbox.com/"
 	userAgent         = "go-dropbox/" + libraryVersion
 
 	defaultMediaType = "application/json; charset=utf-8"
+	binaryMediaType  = "application/octet-stream"
+
+	apiArgHeader    = "Dropbox-API-Arg"
+	apiResultHeader = "Dropbox-API-Result"
+	requestIDHeader = "X-Dropbox-Request-Id"
 )
 
 // RPCRequest is a RPC Style Request. The request and response bodies are both
 // JSON.
 type RPCRequest http.Request
 
+// ContentUploadRequest is a Content-upload Style Request. The request
+// arguments are JSON encoded in the Dropbox-API-Arg header, the request body
+// is binary content and the response body is JSON.
+type ContentUploadRequest http.Request
+
+// ContentDownloadRequest is a Content-download Style Request. The request
+// arguments are JSON encoded in the Dropbox-API-Arg header, the response body
+// is binary content and the response result is JSON encoded in the
+// Dropbox-API-Result header.
+type ContentDownloadRequest http.Request
+
 // A Client manages communication with the Dropbox API.
 type Client struct {
 	// HTTP client used to communicate with the API.
@@ -39,6 +60,10 @@ type Client struct {
 	// API.
 	ContentURL *url.URL
 
+	// Base URL for notification API requests. Defaults to the public Dropbox
+	// notification API.
+	NotifyURL *url.URL
+
 	// User agent used when communicating with the Dropbox API.
 	UserAgent string
 
@@ -49,9 +74,18 @@ type Client struct {
 	// More info at https://www.dropbox.com/developers/core/docs#param.locale
 	Locale string
 
+	// Policy used to retry failed requests. If nil, requests are never
+	// retried. Defaults to DefaultRetryPolicy.
+	RetryPolicy *RetryPolicy
+
+	// Policy used to space the status checks of asynchronous jobs. If nil,
+	// DefaultPollPolicy is used.
+	PollPolicy *PollPolicy
+
 	// Services used for talking to different parts of the Dropbox API.
-	Users *UsersService
-	Files *FilesService
+	Users   *UsersService
+	Files   *FilesService
+	Sharing *SharingService
 }
 
 // NewClient returns a new Dropbox API client. If a nil httpClient is provided,
@@ -64,15 +98,22 @@ func NewClient(httpClient *http.Client) *Client {
 	}
 	baseURL, _ := url.Parse(defaultBaseURL)
 	contentURL, _ := url.Parse(defaultContentURL)
+	notifyURL, _ := url.Parse(defaultNotifyURL)
+	retryPolicy := DefaultRetryPolicy
+	pollPolicy := DefaultPollPolicy
 	c := &Client{
-		client:     httpClient,
-		BaseURL:    baseURL,
-		ContentURL: contentURL,
-		UserAgent:  userAgent,
+		client:      httpClient,
+		BaseURL:     baseURL,
+		ContentURL:  contentURL,
+		NotifyURL:   notifyURL,
+		UserAgent:   userAgent,
+		RetryPolicy: &retryPolicy,
+		PollPolicy:  &pollPolicy,
 	}
 
 	c.Users = &UsersService{c}
 	c.Files = &FilesService{c}
+	c.Sharing = &SharingService{c}
 
 	return c
 }
@@ -95,15 +136,78 @@ func (c *Client) NewRPCRequest(method, urlStr string, body interface{}) (*RPCReq
 
 // DoRPC sends a RPC style request and returns the API response. The API
 // response is JSON decoded and stored in the value pointed to by v, or returned
-// as an error if an API error has occurred.
-func (c *Client) DoRPC(req *RPCRequest, v interface{}) (*http.Response, error) {
-	resp, err := c.client.Do((*http.Request)(req))
+// as an error if an API error has occurred. The request, and any retry of it,
+// is cancelled when ctx is done.
+func (c *Client) DoRPC(ctx context.Context, req *RPCRequest, v interface{}) (*http.Response, error) {
+	return c.do(ctx, (*http.Request)(req), v, nil)
+}
+
+// NewUploadRequest returns a new Content-upload style request. A relative URL
+// can be provided in urlStr, in which case it is resolved relative to the
+// ContentURL of the Client. Arg, if specified, must be a valid JSON marshable
+// value and it is sent in the Dropbox-API-Arg header. Body is streamed as is
+// to the server. If body implements io.Seeker, it is not closed after sending
+// the request, and it is rewound to its current position if the request is
+// retried.
+func (c *Client) NewUploadRequest(method, urlStr string, arg interface{}, body io.Reader) (*ContentUploadRequest, error) {
+	req, err := c.newRequestWithBody(c.ContentURL, method, urlStr, body)
+	if err != nil {
+		return nil, err
+	}
+	if err := rewindable(req, body); err != nil {
+		return nil, err
+	}
+	if err := setAPIArg(req.Header, arg); err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", defaultMediaType)
+	req.Header.Add("Content-Type", binaryMediaType)
+	return (*ContentUploadRequest)(req), nil
+}
+
+// DoUpload sends a Content-upload style request and returns the API response.
+// The API response is JSON decoded and stored in the value pointed to by v, or
+// returned as an error if an API error has occurred. The request, and any
+// retry of it, is cancelled when ctx is done.
+func (c *Client) DoUpload(ctx context.Context, req *ContentUploadRequest, v interface{}) (*http.Response, error) {
+	return c.do(ctx, (*http.Request)(req), v, nil)
+}
+
+// NewDownloadRequest returns a new Content-download style request. A relative
+// URL can be provided in urlStr, in which case it is resolved relative to the
+// ContentURL of the Client. Arg, if specified, must be a valid JSON marshable
+// value and it is sent in the Dropbox-API-Arg header.
+func (c *Client) NewDownloadRequest(method, urlStr string, arg interface{}) (*ContentDownloadRequest, error) {
+	req, err := c.newRequestWithBody(c.ContentURL, method, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := setAPIArg(req.Header, arg); err != nil {
+		return nil, err
+	}
+	return (*ContentDownloadRequest)(req), nil
+}
+
+// DoDownload sends a Content-download style request and returns the API
+// response. The Dropbox-API-Result header is JSON decoded and stored in the
+// value pointed to by v, or returned as an error if an API error has occurred.
+// The returned body streams the downloaded content and it must be closed by
+// the caller. Reading the body fails once ctx is done. On error, the body is
+// nil.
+func (c *Client) DoDownload(ctx context.Context, req *ContentDownloadRequest, v interface{}) (io.ReadCloser, *http.Response, error) {
+	return c.doDownload(ctx, req, v, nil)
+}
+
+// do sends req and JSON decodes the response body in v. If apiErr is not nil,
+// endpoint specific API errors are decoded in it, see checkAPIResponse.
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}, apiErr error) (*http.Response, error) {
+	resp, err := c.send(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	err = checkResponse(resp)
+	err = checkAPIResponse(resp, apiErr)
 	if err != nil {
 		return resp, err
 	}
@@ -116,6 +220,29 @@ func (c *Client) DoRPC(req *RPCRequest, v interface{}) (*http.Response, error) {
 	return resp, err
 }
 
+func (c *Client) doDownload(ctx context.Context, req *ContentDownloadRequest, v interface{}, apiErr error) (io.ReadCloser, *http.Response, error) {
+	resp, err := c.send(ctx, (*http.Request)(req))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = checkAPIResponse(resp, apiErr)
+	if err != nil {
+		resp.Body.Close()
+		return nil, resp, err
+	}
+
+	if v != nil {
+		err = json.Unmarshal([]byte(resp.Header.Get(apiResultHeader)), v)
+		if err != nil {
+			resp.Body.Close()
+			return nil, resp, err
+		}
+	}
+
+	return resp.Body, resp, nil
+}
+
 // UnexpectedError is an error returned by go-dropbox when no more information
 // is provided.
 type UnexpectedError struct{}
@@ -133,6 +260,119 @@ func (e *Error) Error() string {
 	return e.Reason
 }
 
+// APIError is an error returned by the Dropbox API. Endpoint specific errors
+// are stored in Err, so they can be inspected using errors.As:
+//
+//	var lookupErr *dropbox.LookupError
+//	if errors.As(err, &lookupErr) && lookupErr.Tag == "not_found" {
+//		...
+//	}
+type APIError struct {
+	// The HTTP status code of the response.
+	StatusCode int
+
+	// A summary of the error, meant for developers.
+	Summary string
+
+	// A message that can be shown to the end user, if any. Its language is
+	// selected using Client.Locale.
+	UserMessage string
+
+	// The ID of the failed request, useful when contacting Dropbox support.
+	RequestID string
+
+	// The endpoint specific error, if it is known. *AuthError and
+	// *RateLimitError are used for authentication and rate limiting errors of
+	// any endpoint.
+	Err error
+}
+
+func newAPIError(res *http.Response) *APIError {
+	e := &APIError{StatusCode: res.StatusCode}
+	if res.Header != nil {
+		e.RequestID = res.Header.Get(requestIDHeader)
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	if e.Summary != "" {
+		return e.Summary
+	}
+	return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Unwrap returns the endpoint specific error, if any.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// AuthError describes why a request could not be authenticated.
+type AuthError struct {
+	// One of "invalid_access_token", "invalid_select_user",
+	// "invalid_select_admin", "user_suspended", "expired_access_token",
+	// "missing_scope", "route_access_denied" or "other".
+	Tag string `json:".tag"`
+}
+
+var authErrorUnion = newUnion("other", map[string]interface{}{
+	"invalid_access_token": nil,
+	"invalid_select_user":  nil,
+	"invalid_select_admin": nil,
+	"user_suspended":       nil,
+	"expired_access_token": nil,
+	"missing_scope":        nil,
+	"route_access_denied":  nil,
+	"other":                nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *AuthError) UnmarshalJSON(b []byte) error {
+	return authErrorUnion.unmarshal(b, e)
+}
+
+func (e *AuthError) Error() string {
+	return "authentication failed: " + e.Tag
+}
+
+// RateLimitError is returned when too many requests are made in a short
+// period of time.
+type RateLimitError struct {
+	// The reason why the app is being rate limited.
+	Reason RateLimitReason `json:"reason"`
+
+	// The number of seconds that the app should wait before making another
+	// request.
+	RetryAfter uint64 `json:"retry_after"`
+}
+
+func (e *RateLimitError) Error() string {
+	return "rate limited: " + e.Reason.Tag
+}
+
+// RateLimitReason describes why an app is being rate limited.
+type RateLimitReason struct {
+	// One of "too_many_requests", "too_many_write_operations" or "other".
+	Tag string `json:".tag"`
+}
+
+var rateLimitReasonUnion = newUnion("other", map[string]interface{}{
+	"too_many_requests":         nil,
+	"too_many_write_operations": nil,
+	"other":                     nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *RateLimitReason) UnmarshalJSON(b []byte) error {
+	return rateLimitReasonUnion.unmarshal(b, r)
+}
+
+// localizedText is a text with the locale it is written in.
+type localizedText struct {
+	Text   string `json:"text"`
+	Locale string `json:"locale"`
+}
+
 func checkContentType(res *http.Response, ctype string) bool {
 	if _, ok := res.Header["Content-Type"]; !ok {
 		return false
@@ -143,43 +383,81 @@ func checkContentType(res *http.Response, ctype string) bool {
 
 // note that this method do not close response body
 func checkResponse(res *http.Response) error {
+	return checkAPIResponse(res, nil)
+}
+
+// checkAPIResponse is like checkResponse, but if the response carries an
+// endpoint specific error and apiErr is not nil, the error is JSON decoded in
+// apiErr and stored in the returned *APIError.
+func checkAPIResponse(res *http.Response, apiErr error) error {
 	if c := res.StatusCode; 200 <= c && c <= 299 {
 		return nil
 	}
 	if checkContentType(res, "application/json") {
-		var dpErr Error
+		var dpErr struct {
+			Reason      string          `json:"reason"`
+			Summary     string          `json:"error_summary"`
+			UserMessage *localizedText  `json:"user_message"`
+			Err         json.RawMessage `json:"error"`
+		}
 		err := json.NewDecoder(res.Body).Decode(&dpErr)
 		if err != nil {
 			return err
 		}
-		return &dpErr
+		if dpErr.Summary == "" && len(dpErr.Err) == 0 {
+			return &Error{dpErr.Reason}
+		}
+		e := newAPIError(res)
+		e.Summary = dpErr.Summary
+		if dpErr.UserMessage != nil {
+			e.UserMessage = dpErr.UserMessage.Text
+		}
+		switch res.StatusCode {
+		case http.StatusUnauthorized:
+			apiErr = new(AuthError)
+		case http.StatusTooManyRequests:
+			apiErr = new(RateLimitError)
+		}
+		if apiErr != nil && len(dpErr.Err) > 0 {
+			if err := json.Unmarshal(dpErr.Err, apiErr); err != nil {
+				return err
+			}
+			e.Err = apiErr
+		}
+		return e
 	}
 	if checkContentType(res, "text/plain") {
 		buf, err := ioutil.ReadAll(res.Body)
 		if err != nil {
 			return err
 		}
-		return &Error{string(buf)}
+		e := newAPIError(res)
+		e.Summary = string(buf)
+		return e
 	}
 	return &UnexpectedError{}
 }
 
 func (c *Client) newRequest(method, urlStr string, bw func(io.Writer) error) (*http.Request, error) {
-	rel, err := url.Parse(urlStr)
-	if err != nil {
-		return nil, err
-	}
-	u := c.BaseURL.ResolveReference(rel)
-
-	var buffer io.ReadWriter
+	var body io.Reader
 	if bw != nil {
-		buffer = new(bytes.Buffer)
+		buffer := new(bytes.Buffer)
 		if err := bw(buffer); err != nil {
 			return nil, err
 		}
+		body = buffer
 	}
+	return c.newRequestWithBody(c.BaseURL, method, urlStr, body)
+}
 
-	req, err := http.NewRequest(method, u.String(), buffer)
+func (c *Client) newRequestWithBody(baseURL *url.URL, method, urlStr string, body io.Reader) (*http.Request, error) {
+	rel, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	u := baseURL.ResolveReference(rel)
+
+	req, err := http.NewRequest(method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -190,3 +468,36 @@ func (c *Client) newRequest(method, urlStr string, bw func(io.Writer) error) (*h
 
 	return req, nil
 }
+
+// setAPIArg stores arg JSON encoded in the Dropbox-API-Arg header. HTTP
+// headers must be printable ASCII, so any other character is escaped as
+// \uXXXX. Control characters other than DEL are already escaped by
+// json.Marshal.
+func setAPIArg(h http.Header, arg interface{}) error {
+	if arg == nil {
+		return nil
+	}
+	blob, err := json.Marshal(arg)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for _, r := range string(blob) {
+		if r < 0x7f {
+			buf.WriteRune(r)
+			continue
+		}
+		if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+			fmt.Fprintf(&buf, "\\u%04x\\u%04x", r1, r2)
+			continue
+		}
+		fmt.Fprintf(&buf, "\\u%04x", r)
+	}
+	h.Set(apiArgHeader, buf.String())
+	return nil
+}
+
+// Bool returns a pointer to v. It is useful to set optional boolean options.
+func Bool(v bool) *bool {
+	return &v
+}
@@ -6,6 +6,7 @@ package dropbox
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,6 +18,7 @@ import (
 	"reflect"
 	"strings"
 	"testing/iotest"
+	"time"
 
 	"testing"
 )
@@ -48,7 +50,7 @@ func TestNewRPCRequest(t *testing.T) {
 
 	body, _ := ioutil.ReadAll(req.Body)
 	if got, want := strings.TrimSpace(string(body)), outBody; got != want {
-		t.Errorf("TestNewRPCRequest(%q) Body is %v, want %v", inBody, got, want)
+		t.Errorf("TestNewRPCRequest(%v) Body is %v, want %v", inBody, got, want)
 	}
 
 	if got, want := req.Header.Get("Accept"), "application/json"; strings.HasPrefix(want, got) {
@@ -64,7 +66,7 @@ func TestNewRPCRequest_invalidJSON(t *testing.T) {
 	c := NewClient(nil)
 
 	type T struct {
-		A map[int]interface{}
+		A chan int
 	}
 	_, err := c.NewRPCRequest("GET", "/", &T{})
 
@@ -105,7 +107,7 @@ func TestDoRPC(t *testing.T) {
 
 	req, _ := client.NewRPCRequest("GET", "/", &foo{"in"})
 	body := new(foo)
-	client.DoRPC(req, body)
+	client.DoRPC(context.Background(), req, body)
 
 	want := &foo{"out"}
 	if !reflect.DeepEqual(body, want) {
@@ -123,7 +125,7 @@ func TestDoRPC_httpError(t *testing.T) {
 	})
 
 	req, _ := client.NewRPCRequest("GET", "/", nil)
-	_, err := client.DoRPC(req, nil)
+	_, err := client.DoRPC(context.Background(), req, nil)
 
 	if _, ok := err.(*UnexpectedError); !ok {
 		t.Errorf("Expected an UnexpectedError; got %#v.", err)
@@ -139,7 +141,7 @@ func TestDoRPC_redirectLoop(t *testing.T) {
 	})
 
 	req, _ := client.NewRPCRequest("GET", "/", nil)
-	_, err := client.DoRPC(req, nil)
+	_, err := client.DoRPC(context.Background(), req, nil)
 
 	if err == nil {
 		t.Error("Expected error to be returned.")
@@ -149,6 +151,156 @@ func TestDoRPC_redirectLoop(t *testing.T) {
 	}
 }
 
+func TestNewUploadRequest(t *testing.T) {
+	c := NewClient(nil)
+
+	arg := struct {
+		Path string `json:"path"`
+	}{"/foo.txt"}
+	req, err := c.NewUploadRequest("POST", "foo", &arg, strings.NewReader("content"))
+	if err != nil {
+		t.Errorf("NewUploadRequest returned unexpected error: %v", err)
+	}
+
+	if got, want := req.URL.String(), defaultContentURL+"foo"; got != want {
+		t.Errorf("NewUploadRequest URL is %v, want %v", got, want)
+	}
+
+	if got, want := req.Header.Get("Dropbox-API-Arg"), `{"path":"/foo.txt"}`; got != want {
+		t.Errorf("NewUploadRequest Dropbox-API-Arg Header is %v, want %v", got, want)
+	}
+
+	if got, want := req.Header.Get("Content-Type"), "application/octet-stream"; got != want {
+		t.Errorf("NewUploadRequest Content-Type Header is %v, want %v", got, want)
+	}
+
+	body, _ := ioutil.ReadAll(req.Body)
+	if got, want := string(body), "content"; got != want {
+		t.Errorf("NewUploadRequest Body is %v, want %v", got, want)
+	}
+}
+
+func TestNewUploadRequest_nonASCIIArg(t *testing.T) {
+	c := NewClient(nil)
+
+	arg := struct {
+		Path string `json:"path"`
+	}{"/ñ😀\x7f"}
+	req, err := c.NewUploadRequest("POST", "foo", &arg, nil)
+	if err != nil {
+		t.Errorf("NewUploadRequest returned unexpected error: %v", err)
+	}
+
+	if got, want := req.Header.Get("Dropbox-API-Arg"), `{"path":"/\u00f1\ud83d\ude00\u007f"}`; got != want {
+		t.Errorf("NewUploadRequest Dropbox-API-Arg Header is %v, want %v", got, want)
+	}
+}
+
+func TestDoUpload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	type foo struct {
+		A string
+	}
+
+	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Dropbox-API-Arg"), `{"A":"in"}`; got != want {
+			t.Errorf("Dropbox-API-Arg Header = %v, want %v", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := string(body), "binary content"; got != want {
+			t.Errorf("Request body = %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"A":"out"}`)
+	})
+
+	req, _ := client.NewUploadRequest("POST", "upload", &foo{"in"}, strings.NewReader("binary content"))
+	body := new(foo)
+	_, err := client.DoUpload(context.Background(), req, body)
+	if err != nil {
+		t.Errorf("DoUpload returned unexpected error: %v", err)
+	}
+
+	want := &foo{"out"}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("Response body = %v, want %v", body, want)
+	}
+}
+
+func TestNewDownloadRequest(t *testing.T) {
+	c := NewClient(nil)
+
+	arg := struct {
+		Path string `json:"path"`
+	}{"/foo.txt"}
+	req, err := c.NewDownloadRequest("POST", "foo", &arg)
+	if err != nil {
+		t.Errorf("NewDownloadRequest returned unexpected error: %v", err)
+	}
+
+	if got, want := req.URL.String(), defaultContentURL+"foo"; got != want {
+		t.Errorf("NewDownloadRequest URL is %v, want %v", got, want)
+	}
+
+	if got, want := req.Header.Get("Dropbox-API-Arg"), `{"path":"/foo.txt"}`; got != want {
+		t.Errorf("NewDownloadRequest Dropbox-API-Arg Header is %v, want %v", got, want)
+	}
+}
+
+func TestDoDownload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	type foo struct {
+		A string
+	}
+
+	mux.HandleFunc("/download", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Dropbox-API-Arg"), `{"A":"in"}`; got != want {
+			t.Errorf("Dropbox-API-Arg Header = %v, want %v", got, want)
+		}
+		w.Header().Set("Dropbox-API-Result", `{"A":"out"}`)
+		fmt.Fprint(w, "binary content")
+	})
+
+	req, _ := client.NewDownloadRequest("POST", "download", &foo{"in"})
+	result := new(foo)
+	body, _, err := client.DoDownload(context.Background(), req, result)
+	if err != nil {
+		t.Fatalf("DoDownload returned unexpected error: %v", err)
+	}
+	defer body.Close()
+
+	if want := (&foo{"out"}); !reflect.DeepEqual(result, want) {
+		t.Errorf("Result = %v, want %v", result, want)
+	}
+	content, _ := ioutil.ReadAll(body)
+	if got, want := string(content), "binary content"; got != want {
+		t.Errorf("Response body = %v, want %v", got, want)
+	}
+}
+
+func TestDoDownload_httpError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(409)
+		fmt.Fprint(w, "db-error")
+	})
+
+	req, _ := client.NewDownloadRequest("POST", "download", nil)
+	body, _, err := client.DoDownload(context.Background(), req, nil)
+	if body != nil {
+		t.Error("DoDownload returned a body on error")
+	}
+	if got, want := err, (&APIError{StatusCode: 409, Summary: "db-error"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("DoDownload returned %#v, want %#v", got, want)
+	}
+}
+
 func TestNewClient(t *testing.T) {
 	c := NewClient(nil)
 
@@ -161,9 +313,18 @@ func TestNewClient(t *testing.T) {
 	if got, want := c.ContentURL.String(), defaultContentURL; got != want {
 		t.Errorf("NewClient ContentURL is %v, want %v", got, want)
 	}
+	if got, want := c.NotifyURL.String(), defaultNotifyURL; got != want {
+		t.Errorf("NewClient NotifyURL is %v, want %v", got, want)
+	}
 	if got, want := c.UserAgent, userAgent; got != want {
 		t.Errorf("NewClient UserAgent is %v, want %v", got, want)
 	}
+	if got, want := *c.RetryPolicy, DefaultRetryPolicy; got != want {
+		t.Errorf("NewClient RetryPolicy is %v, want %v", got, want)
+	}
+	if got, want := *c.PollPolicy, DefaultPollPolicy; got != want {
+		t.Errorf("NewClient PollPolicy is %v, want %v", got, want)
+	}
 }
 
 func TestCheckContentType(t *testing.T) {
@@ -220,7 +381,7 @@ func TestCheckResponse(t *testing.T) {
 	res.Header = http.Header{}
 	res.Header.Add("Content-Type", "text/plain")
 	res.Body = ioutil.NopCloser(bytes.NewBufferString("db-error"))
-	if got, want := checkResponse(res), &(Error{"db-error"}); !reflect.DeepEqual(got, want) {
+	if got, want := checkResponse(res), &(APIError{StatusCode: 500, Summary: "db-error"}); !reflect.DeepEqual(got, want) {
 		t.Errorf("checkResponse({StatusCode: 500, \"text/plain\", Body:\"db-error\"}) returned %#v, want %#v", got, want)
 	}
 
@@ -252,6 +413,46 @@ func TestCheckResponse(t *testing.T) {
 	}
 }
 
+func TestCheckResponse_apiError(t *testing.T) {
+	res := &http.Response{}
+	res.StatusCode = 409
+	res.Header = http.Header{}
+	res.Header.Set("Content-Type", "application/json")
+	res.Header.Set("X-Dropbox-Request-Id", "1234")
+	res.Body = ioutil.NopCloser(bytes.NewBufferString(`{"error_summary":"path/not_found/..","error":{".tag":"path","path":{".tag":"not_found"}},"user_message":{"text":"Not found","locale":"en"}}`))
+	err := checkAPIResponse(res, new(DownloadError))
+
+	want := &APIError{
+		StatusCode:  409,
+		Summary:     "path/not_found/..",
+		UserMessage: "Not found",
+		RequestID:   "1234",
+		Err:         &DownloadError{Tag: "path", Path: &LookupError{Tag: "not_found"}},
+	}
+	if !reflect.DeepEqual(err, want) {
+		t.Errorf("checkAPIResponse returned %#v, want %#v", err, want)
+	}
+
+	var lookupErr *LookupError
+	if !errors.As(err, &lookupErr) || lookupErr.Tag != "not_found" {
+		t.Errorf("errors.As(%v, *LookupError) did not find the lookup error", err)
+	}
+}
+
+func TestCheckResponse_authError(t *testing.T) {
+	res := &http.Response{}
+	res.StatusCode = 401
+	res.Header = http.Header{}
+	res.Header.Set("Content-Type", "application/json")
+	res.Body = ioutil.NopCloser(bytes.NewBufferString(`{"error_summary":"invalid_access_token/..","error":{".tag":"invalid_access_token"}}`))
+	err := checkAPIResponse(res, new(DownloadError))
+
+	var authErr *AuthError
+	if !errors.As(err, &authErr) || authErr.Tag != "invalid_access_token" {
+		t.Errorf("checkAPIResponse returned %#v, want an invalid_access_token *AuthError", err)
+	}
+}
+
 func TestNewRequest(t *testing.T) {
 	c := NewClient(nil)
 
@@ -326,6 +527,17 @@ func setup() {
 	client = NewClient(nil)
 	url, _ := url.Parse(server.URL)
 	client.BaseURL = url
+	client.ContentURL = url
+	client.NotifyURL = url
+	client.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  10 * time.Millisecond,
+	}
+	client.PollPolicy = &PollPolicy{
+		MinInterval: time.Millisecond,
+		MaxInterval: 10 * time.Millisecond,
+	}
 }
 
 func teardown() {
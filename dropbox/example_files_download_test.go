@@ -0,0 +1,29 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+func ExampleFilesService_Download() {
+	c := dropbox.NewClient(nil)
+
+	meta, body, _, err := c.Files.Download(context.Background(), "/logs/server.log", nil)
+	if err != nil {
+		fmt.Printf("Error: %s\n", err.Error())
+		return
+	}
+	defer body.Close()
+	fmt.Printf("%s (%d bytes):\n", meta.Name, meta.Size)
+	io.Copy(os.Stdout, body)
+
+	// Output:
+	// server.log (35 bytes):
+	// 2015-05-12 15:50:38 server started
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"fmt"
+)
+
+func ExampleFilesService_ListFolderIterator() {
+	c := dropbox.NewClient(nil)
+
+	it := c.Files.ListFolderIterator(context.Background(), "/photos", &ListFolderOptions{
+		IncludeDeleted: true,
+		Limit:          2,
+	})
+	for it.Next() {
+		fmt.Println(it.Metadata().GetPathDisplay())
+	}
+	if err := it.Err(); err != nil {
+		fmt.Printf("Error: %s\n", err.Error())
+		return
+	}
+	// Store the cursor to get the folder changes later.
+	fmt.Println("cursor:", it.Cursor())
+
+	// Output:
+	// /photos/Holidays
+	// /photos/James.jpg
+	// /photos/Mary.jpg
+	// /photos/Richard.jpg
+	// cursor: photos-end
+}
@@ -4,7 +4,10 @@
 
 package dropbox
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 func ExampleFilesService_ListFolder() {
 	// Use golang.org/x/oauth2 for authentication:
@@ -13,18 +16,22 @@ func ExampleFilesService_ListFolder() {
 	// c := dropbox.NewClient(tc)
 	c := dropbox.NewClient(nil)
 
-	entries, _, err := c.Files.ListFolder("/photos")
+	entries, _, err := c.Files.ListFolder(context.Background(), "/photos")
 	if err != nil {
 		fmt.Printf("Error: %s\n", err.Error())
 		return
 	}
 	for _, entry := range entries {
-		fmt.Println(entry.Name)
+		switch e := entry.(type) {
+		case *FolderMetadata:
+			fmt.Printf("%s/\n", e.Name)
+		case *FileMetadata:
+			fmt.Printf("%s (%d bytes)\n", e.Name, e.Size)
+		}
 	}
 
 	// Output:
-	// James.jpg
-	// Mary.jpg
-	// Richard.jpg
-	// Susan.jpg
+	// Holidays/
+	// James.jpg (125734 bytes)
+	// Mary.jpg (98121 bytes)
 }
@@ -0,0 +1,29 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func ExampleFilesService_Upload() {
+	c := dropbox.NewClient(nil)
+
+	r := strings.NewReader("Quarterly report")
+	meta, _, err := c.Files.Upload(context.Background(), "/reports/q1.txt", r, &CommitInfo{
+		Mode:       WriteModeOverwrite(),
+		Autorename: true,
+	})
+	if err != nil {
+		fmt.Printf("Error: %s\n", err.Error())
+		return
+	}
+	fmt.Printf("%s (%d bytes)\n", meta.PathDisplay, meta.Size)
+
+	// Output:
+	// /reports/q1.txt (16 bytes)
+}
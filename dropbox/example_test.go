@@ -6,10 +6,14 @@ package dropbox
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path"
 	"testing"
 )
 
@@ -25,6 +29,8 @@ func (d dropboxPackage) NewClient(c *http.Client) *Client {
 	dc := NewClient(c)
 	url, _ := url.Parse(exampleServer.URL)
 	dc.BaseURL = url
+	dc.ContentURL = url
+	dc.NotifyURL = url
 	return dc
 }
 
@@ -39,17 +45,58 @@ func setupExampleServer() {
 			json.NewEncoder(w).Encode(info)
 		})
 
+	// The photos folder is listed in two pages.
 	exampleMux.HandleFunc("/2-beta/files/list_folder",
 		func(w http.ResponseWriter, r *http.Request) {
-			resp := listResponse{
-				Entries: []Entry{
-					Entry{Name: "James.jpg"},
-					Entry{Name: "Mary.jpg"},
-					Entry{Name: "Richard.jpg"},
-					Entry{Name: "Susan.jpg"},
-				},
+			fmt.Fprint(w, `{
+				"entries": [
+					{".tag": "folder", "name": "Holidays", "path_display": "/photos/Holidays"},
+					{".tag": "file", "name": "James.jpg", "path_display": "/photos/James.jpg", "size": 125734}
+				],
+				"cursor": "photos-page-2",
+				"has_more": true
+			}`)
+		})
+
+	exampleMux.HandleFunc("/2-beta/files/list_folder/continue",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{
+				"entries": [
+					{".tag": "file", "name": "Mary.jpg", "path_display": "/photos/Mary.jpg", "size": 98121},
+					{".tag": "deleted", "name": "Richard.jpg", "path_display": "/photos/Richard.jpg"}
+				],
+				"cursor": "photos-end",
+				"has_more": false
+			}`)
+		})
+
+	exampleMux.HandleFunc("/2-beta/files/upload",
+		func(w http.ResponseWriter, r *http.Request) {
+			var arg CommitInfo
+			json.Unmarshal([]byte(r.Header.Get("Dropbox-API-Arg")), &arg)
+			size, _ := io.Copy(ioutil.Discard, r.Body)
+			meta := FileMetadata{
+				Name:        path.Base(arg.Path),
+				PathDisplay: arg.Path,
+				Size:        uint64(size),
+			}
+			json.NewEncoder(w).Encode(meta)
+		})
+
+	exampleMux.HandleFunc("/2-beta/files/download",
+		func(w http.ResponseWriter, r *http.Request) {
+			var arg struct {
+				Path string `json:"path"`
 			}
-			json.NewEncoder(w).Encode(resp)
+			json.Unmarshal([]byte(r.Header.Get("Dropbox-API-Arg")), &arg)
+			content := "2015-05-12 15:50:38 server started\n"
+			meta, _ := json.Marshal(FileMetadata{
+				Name:        path.Base(arg.Path),
+				PathDisplay: arg.Path,
+				Size:        uint64(len(content)),
+			})
+			w.Header().Set("Dropbox-API-Result", string(meta))
+			io.WriteString(w, content)
 		})
 }
 
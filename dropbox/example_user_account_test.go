@@ -4,7 +4,10 @@
 
 package dropbox
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 func ExampleUsersService_GetAccount() {
 	// Use golang.org/x/oauth2 for authentication:
@@ -13,7 +16,7 @@ func ExampleUsersService_GetAccount() {
 	// c := dropbox.NewClient(tc)
 	c := dropbox.NewClient(nil)
 
-	account, _, err := c.Users.GetAccount()
+	account, _, err := c.Users.GetAccount(context.Background())
 	if err != nil {
 		fmt.Printf("Error: %s\n", err.Error())
 		return
@@ -0,0 +1,372 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// BatchResult is the result of an entry of a batch job.
+type BatchResult struct {
+	// The metadata of the entry, if the operation succeeded.
+	Metadata Metadata
+
+	// The error of the operation, if it failed.
+	Err error
+}
+
+// BatchJob is a copy, move or delete batch started by CopyBatch, MoveBatch
+// or DeleteBatch. Batches may complete when started, or run as an
+// asynchronous job whose status is checked with CheckBatchJob or awaited
+// with WaitBatchJob.
+type BatchJob struct {
+	// The ID of the asynchronous job. Empty if the batch completed when it
+	// was started.
+	AsyncJobID string
+
+	// The results of the batch, in the same order as its entries. Only set
+	// once the job is done.
+	Results []BatchResult
+
+	done     bool
+	checkURL string
+	status   batchStatus
+}
+
+// Done reports whether the job has completed.
+func (j *BatchJob) Done() bool {
+	return j.done
+}
+
+// batchStatus is a launch or check result of a batch job.
+type batchStatus interface {
+	AsyncStatus
+
+	// update applies the status to job. It returns an error if the job
+	// failed as a whole.
+	update(job *BatchJob) error
+}
+
+// CopyBatch copies several files or folders at once. Only the Autorename
+// option of opts is used.
+func (s *FilesService) CopyBatch(ctx context.Context, entries []RelocationPath, opts *RelocationOptions) (*BatchJob, *http.Response, error) {
+	if opts == nil {
+		opts = &RelocationOptions{}
+	}
+	arg := struct {
+		Entries    []RelocationPath `json:"entries"`
+		Autorename bool             `json:"autorename"`
+	}{entries, opts.Autorename}
+	return s.startBatch(ctx, "2-beta/files/copy_batch_v2", "2-beta/files/copy_batch/check_v2", &arg, new(relocationBatchStatus))
+}
+
+// MoveBatch moves several files or folders at once. The AllowSharedFolder
+// option of opts is not used.
+func (s *FilesService) MoveBatch(ctx context.Context, entries []RelocationPath, opts *RelocationOptions) (*BatchJob, *http.Response, error) {
+	if opts == nil {
+		opts = &RelocationOptions{}
+	}
+	arg := struct {
+		Entries                []RelocationPath `json:"entries"`
+		Autorename             bool             `json:"autorename"`
+		AllowOwnershipTransfer bool             `json:"allow_ownership_transfer"`
+	}{entries, opts.Autorename, opts.AllowOwnershipTransfer}
+	return s.startBatch(ctx, "2-beta/files/move_batch_v2", "2-beta/files/move_batch/check_v2", &arg, new(relocationBatchStatus))
+}
+
+// DeleteBatchEntry is a file or folder deleted by DeleteBatch.
+type DeleteBatchEntry struct {
+	// The path of the file or folder to delete.
+	Path string `json:"path"`
+
+	// If not empty, the file is only deleted if its current revision is
+	// ParentRev.
+	ParentRev string `json:"parent_rev,omitempty"`
+}
+
+// DeleteBatch deletes several files or folders at once.
+func (s *FilesService) DeleteBatch(ctx context.Context, entries []DeleteBatchEntry) (*BatchJob, *http.Response, error) {
+	arg := struct {
+		Entries []DeleteBatchEntry `json:"entries"`
+	}{entries}
+	return s.startBatch(ctx, "2-beta/files/delete_batch", "2-beta/files/delete_batch/check", &arg, new(deleteBatchStatus))
+}
+
+// CheckBatchJob checks the status of job, updating it. Errors checking the
+// status are returned as an *APIError holding a *PollError.
+func (s *FilesService) CheckBatchJob(ctx context.Context, job *BatchJob) (*http.Response, error) {
+	if job.done {
+		return nil, nil
+	}
+
+	resp, err := s.client.CheckAsyncJob(ctx, job.checkURL, job.AsyncJobID, job.status)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, job.status.update(job)
+}
+
+// WaitBatchJob waits until job is done, checking its status as configured by
+// the PollPolicy of the client, and returns its results.
+func (s *FilesService) WaitBatchJob(ctx context.Context, job *BatchJob) ([]BatchResult, error) {
+	if job.done {
+		return job.Results, nil
+	}
+
+	if _, err := s.client.WaitAsyncJob(ctx, job.checkURL, job.AsyncJobID, job.status, nil); err != nil {
+		return nil, err
+	}
+	if err := job.status.update(job); err != nil {
+		return nil, err
+	}
+
+	return job.Results, nil
+}
+
+// startBatch launches a batch job and returns it.
+func (s *FilesService) startBatch(ctx context.Context, urlStr, checkURL string, arg interface{}, status batchStatus) (*BatchJob, *http.Response, error) {
+	req, err := s.client.NewRPCRequest("POST", urlStr, arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := s.client.DoRPC(ctx, req, status)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	job := &BatchJob{checkURL: checkURL, status: status}
+	if err := status.update(job); err != nil {
+		return nil, resp, err
+	}
+
+	return job, resp, nil
+}
+
+// errBatchStatus returns the error reported for a batch job in an
+// unexpected state.
+func errBatchStatus(tag string) error {
+	return errors.New("dropbox: batch job finished with status " + tag)
+}
+
+// RelocationBatchErrorEntry describes why an entry of a copy or move batch
+// failed.
+type RelocationBatchErrorEntry struct {
+	// One of "relocation_error", "internal_error",
+	// "too_many_write_operations" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "relocation_error".
+	RelocationError *RelocationError `json:"relocation_error,omitempty"`
+}
+
+var relocationBatchErrorEntryUnion = newUnion("other", map[string]interface{}{
+	"relocation_error":          RelocationError{},
+	"internal_error":            nil,
+	"too_many_write_operations": nil,
+	"other":                     nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *RelocationBatchErrorEntry) UnmarshalJSON(b []byte) error {
+	return relocationBatchErrorEntryUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the relocation error, if any.
+func (e *RelocationBatchErrorEntry) Unwrap() error {
+	if e.RelocationError == nil {
+		return nil
+	}
+	return e.RelocationError
+}
+
+func (e *RelocationBatchErrorEntry) Error() string {
+	if e.RelocationError != nil {
+		return e.RelocationError.Error()
+	}
+	return "relocation failed: " + e.Tag
+}
+
+// relocationBatchStatus is either the launch result of a copy or move batch
+// or the result of checking its status.
+type relocationBatchStatus struct {
+	// One of "async_job_id", "in_progress", "complete" or "other".
+	Tag        string                 `json:".tag"`
+	AsyncJobID string                 `json:"async_job_id"`
+	Complete   *relocationBatchResult `json:"complete"`
+}
+
+var relocationBatchStatusUnion = newUnion("other", map[string]interface{}{
+	"async_job_id": "",
+	"in_progress":  nil,
+	"complete":     relocationBatchResult{},
+	"other":        nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *relocationBatchStatus) UnmarshalJSON(b []byte) error {
+	return relocationBatchStatusUnion.unmarshal(b, s)
+}
+
+func (s *relocationBatchStatus) Pending() bool {
+	return s.Tag == "async_job_id" || s.Tag == "in_progress"
+}
+
+func (s *relocationBatchStatus) update(job *BatchJob) error {
+	switch s.Tag {
+	case "async_job_id":
+		job.AsyncJobID = s.AsyncJobID
+	case "in_progress":
+	case "complete":
+		if s.Complete == nil {
+			return errBatchStatus(s.Tag)
+		}
+		job.Results = make([]BatchResult, len(s.Complete.Entries))
+		for i, e := range s.Complete.Entries {
+			switch {
+			case e.Success != nil:
+				job.Results[i].Metadata = e.Success.Metadata
+			case e.Failure != nil:
+				job.Results[i].Err = e.Failure
+			default:
+				job.Results[i].Err = &RelocationBatchErrorEntry{Tag: e.Tag}
+			}
+		}
+		job.done = true
+	default:
+		return errBatchStatus(s.Tag)
+	}
+	return nil
+}
+
+type relocationBatchResult struct {
+	Entries []relocationBatchResultEntry `json:"entries"`
+}
+
+type relocationBatchResultEntry struct {
+	// One of "success", "failure" or "other".
+	Tag     string                     `json:".tag"`
+	Success *metadataValue             `json:"success"`
+	Failure *RelocationBatchErrorEntry `json:"failure"`
+}
+
+var relocationBatchResultEntryUnion = newUnion("other", map[string]interface{}{
+	"success": metadataValue{},
+	"failure": RelocationBatchErrorEntry{},
+	"other":   nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *relocationBatchResultEntry) UnmarshalJSON(b []byte) error {
+	return relocationBatchResultEntryUnion.unmarshal(b, e)
+}
+
+// DeleteBatchError describes why a delete batch failed as a whole.
+type DeleteBatchError struct {
+	// Either "too_many_write_operations" or "other".
+	Tag string `json:".tag"`
+}
+
+var deleteBatchErrorUnion = newUnion("other", map[string]interface{}{
+	"too_many_write_operations": nil,
+	"other":                     nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *DeleteBatchError) UnmarshalJSON(b []byte) error {
+	return deleteBatchErrorUnion.unmarshal(b, e)
+}
+
+func (e *DeleteBatchError) Error() string {
+	return "delete batch failed: " + e.Tag
+}
+
+// deleteBatchStatus is either the launch result of a delete batch or the
+// result of checking its status.
+type deleteBatchStatus struct {
+	// One of "async_job_id", "in_progress", "complete", "failed" or "other".
+	Tag        string             `json:".tag"`
+	AsyncJobID string             `json:"async_job_id"`
+	Complete   *deleteBatchResult `json:"complete"`
+	Failed     *DeleteBatchError  `json:"failed"`
+}
+
+var deleteBatchStatusUnion = newUnion("other", map[string]interface{}{
+	"async_job_id": "",
+	"in_progress":  nil,
+	"complete":     deleteBatchResult{},
+	"failed":       DeleteBatchError{},
+	"other":        nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *deleteBatchStatus) UnmarshalJSON(b []byte) error {
+	return deleteBatchStatusUnion.unmarshal(b, s)
+}
+
+func (s *deleteBatchStatus) Pending() bool {
+	return s.Tag == "async_job_id" || s.Tag == "in_progress"
+}
+
+func (s *deleteBatchStatus) update(job *BatchJob) error {
+	switch s.Tag {
+	case "async_job_id":
+		job.AsyncJobID = s.AsyncJobID
+	case "in_progress":
+	case "complete":
+		if s.Complete == nil {
+			return errBatchStatus(s.Tag)
+		}
+		job.Results = make([]BatchResult, len(s.Complete.Entries))
+		for i, e := range s.Complete.Entries {
+			switch {
+			case e.Success != nil:
+				job.Results[i].Metadata = e.Success.Metadata.Metadata
+			case e.Failure != nil:
+				job.Results[i].Err = e.Failure
+			default:
+				job.Results[i].Err = &DeleteError{Tag: e.Tag}
+			}
+		}
+		job.done = true
+	case "failed":
+		if s.Failed != nil {
+			return s.Failed
+		}
+		return &DeleteBatchError{Tag: "other"}
+	default:
+		return errBatchStatus(s.Tag)
+	}
+	return nil
+}
+
+type deleteBatchResult struct {
+	Entries []deleteBatchResultEntry `json:"entries"`
+}
+
+type deleteBatchResultEntry struct {
+	// One of "success", "failure" or "other".
+	Tag     string                 `json:".tag"`
+	Success *deleteBatchResultData `json:"success"`
+	Failure *DeleteError           `json:"failure"`
+}
+
+type deleteBatchResultData struct {
+	Metadata metadataValue `json:"metadata"`
+}
+
+var deleteBatchResultEntryUnion = newUnion("other", map[string]interface{}{
+	"success": deleteBatchResultData{},
+	"failure": DeleteError{},
+	"other":   nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *deleteBatchResultEntry) UnmarshalJSON(b []byte) error {
+	return deleteBatchResultEntryUnion.unmarshal(b, e)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCopyBatch_async(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/copy_batch_v2", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"entries":[{"from_path":"/a","to_path":"/b"},{"from_path":"/c","to_path":"/d"}],"autorename":false}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("copy_batch_v2 body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{".tag":"async_job_id","async_job_id":"job-1"}`)
+	})
+	checks := 0
+	mux.HandleFunc("/2-beta/files/copy_batch/check_v2", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"async_job_id":"job-1"}`; got != want {
+			t.Errorf("copy_batch/check_v2 body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		checks++
+		if checks == 1 {
+			fmt.Fprint(w, `{".tag":"in_progress"}`)
+			return
+		}
+		fmt.Fprint(w, `{".tag":"complete","entries":[`+
+			`{".tag":"success","success":{".tag":"file","name":"b","path_lower":"/b"}},`+
+			`{".tag":"failure","failure":{".tag":"relocation_error","relocation_error":{".tag":"from_lookup","from_lookup":{".tag":"not_found"}}}}]}`)
+	})
+
+	ctx := context.Background()
+	job, _, err := client.Files.CopyBatch(ctx, []RelocationPath{{"/a", "/b"}, {"/c", "/d"}}, nil)
+	if err != nil {
+		t.Fatalf("CopyBatch returned unexpected error: %v", err)
+	}
+	if job.Done() || job.AsyncJobID != "job-1" {
+		t.Fatalf("CopyBatch returned job %+v, want pending job-1", job)
+	}
+	results, err := client.Files.WaitBatchJob(ctx, job)
+	if err != nil {
+		t.Fatalf("WaitBatchJob returned unexpected error: %v", err)
+	}
+	if checks != 2 {
+		t.Errorf("WaitBatchJob checked the job %v times, want 2", checks)
+	}
+	if len(results) != 2 {
+		t.Fatalf("WaitBatchJob returned %v results, want 2", len(results))
+	}
+	if file, ok := results[0].Metadata.(*FileMetadata); !ok || file.PathLower != "/b" {
+		t.Errorf("WaitBatchJob results[0] is %+v, want /b file", results[0])
+	}
+	var lookupErr *LookupError
+	if !errors.As(results[1].Err, &lookupErr) || lookupErr.Tag != "not_found" {
+		t.Errorf("WaitBatchJob results[1].Err is %#v, want a not_found *LookupError", results[1].Err)
+	}
+}
+
+func TestDeleteBatch_complete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/delete_batch", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"entries":[{"path":"/a","parent_rev":"1"},{"path":"/b"}]}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("delete_batch body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{".tag":"complete","entries":[`+
+			`{".tag":"success","metadata":{".tag":"deleted","name":"a","path_lower":"/a"}},`+
+			`{".tag":"failure","failure":{".tag":"path_lookup","path_lookup":{".tag":"not_found"}}}]}`)
+	})
+
+	job, _, err := client.Files.DeleteBatch(context.Background(), []DeleteBatchEntry{{"/a", "1"}, {Path: "/b"}})
+	if err != nil {
+		t.Fatalf("DeleteBatch returned unexpected error: %v", err)
+	}
+	if !job.Done() || job.AsyncJobID != "" {
+		t.Fatalf("DeleteBatch returned job %+v, want a completed job", job)
+	}
+	if _, ok := job.Results[0].Metadata.(*DeletedMetadata); !ok {
+		t.Errorf("DeleteBatch Results[0] is %+v, want deleted metadata", job.Results[0])
+	}
+	var deleteErr *DeleteError
+	if !errors.As(job.Results[1].Err, &deleteErr) || deleteErr.Tag != "path_lookup" {
+		t.Errorf("DeleteBatch Results[1].Err is %#v, want a path_lookup *DeleteError", job.Results[1].Err)
+	}
+}
+
+func TestDeleteBatch_failed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/delete_batch", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{".tag":"async_job_id","async_job_id":"job-2"}`)
+	})
+	mux.HandleFunc("/2-beta/files/delete_batch/check", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{".tag":"failed","failed":{".tag":"too_many_write_operations"}}`)
+	})
+
+	ctx := context.Background()
+	job, _, err := client.Files.DeleteBatch(ctx, []DeleteBatchEntry{{Path: "/a"}})
+	if err != nil {
+		t.Fatalf("DeleteBatch returned unexpected error: %v", err)
+	}
+	_, err = client.Files.WaitBatchJob(ctx, job)
+	var batchErr *DeleteBatchError
+	if !errors.As(err, &batchErr) || batchErr.Tag != "too_many_write_operations" {
+		t.Errorf("WaitBatchJob error is %#v, want a too_many_write_operations *DeleteBatchError", err)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// CopyReference is a reference to a file or folder that can be saved into
+// another Dropbox account with CopyReferenceSave.
+type CopyReference struct {
+	// The metadata of the referenced file or folder.
+	Metadata Metadata
+
+	// The copy reference.
+	CopyReference string
+
+	// The time the copy reference expires.
+	Expires time.Time
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *CopyReference) UnmarshalJSON(b []byte) error {
+	var ref struct {
+		Metadata      metadataValue `json:"metadata"`
+		CopyReference string        `json:"copy_reference"`
+		Expires       time.Time     `json:"expires"`
+	}
+	if err := json.Unmarshal(b, &ref); err != nil {
+		return err
+	}
+	*r = CopyReference{ref.Metadata.Metadata, ref.CopyReference, ref.Expires}
+	return nil
+}
+
+// GetCopyReferenceError describes why a copy reference could not be got.
+type GetCopyReferenceError struct {
+	// Either "path" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *LookupError `json:"path,omitempty"`
+}
+
+var getCopyReferenceErrorUnion = newUnion("other", map[string]interface{}{
+	"path":  LookupError{},
+	"other": nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *GetCopyReferenceError) UnmarshalJSON(b []byte) error {
+	return getCopyReferenceErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the lookup error, if any.
+func (e *GetCopyReferenceError) Unwrap() error {
+	if e.Path == nil {
+		return nil
+	}
+	return e.Path
+}
+
+func (e *GetCopyReferenceError) Error() string {
+	if e.Tag == "path" && e.Path != nil {
+		return "get copy reference failed: path/" + e.Path.Error()
+	}
+	return "get copy reference failed: " + e.Tag
+}
+
+// CopyReferenceGet returns a copy reference to the file or folder at path.
+// Errors are returned as an *APIError holding a *GetCopyReferenceError.
+func (s *FilesService) CopyReferenceGet(ctx context.Context, path string) (*CopyReference, *http.Response, error) {
+	arg := struct {
+		Path string `json:"path"`
+	}{path}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/files/copy_reference/get", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	ref := new(CopyReference)
+	resp, err := s.client.do(ctx, (*http.Request)(req), ref, new(GetCopyReferenceError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ref, resp, nil
+}
+
+// SaveCopyReferenceError describes why a copy reference could not be saved.
+type SaveCopyReferenceError struct {
+	// One of "path", "invalid_copy_reference", "no_permission", "not_found",
+	// "too_many_files" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *WriteError `json:"path,omitempty"`
+}
+
+var saveCopyReferenceErrorUnion = newUnion("other", map[string]interface{}{
+	"path":                   WriteError{},
+	"invalid_copy_reference": nil,
+	"no_permission":          nil,
+	"not_found":              nil,
+	"too_many_files":         nil,
+	"other":                  nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SaveCopyReferenceError) UnmarshalJSON(b []byte) error {
+	return saveCopyReferenceErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the write error, if any.
+func (e *SaveCopyReferenceError) Unwrap() error {
+	if e.Path == nil {
+		return nil
+	}
+	return e.Path
+}
+
+func (e *SaveCopyReferenceError) Error() string {
+	if e.Tag == "path" && e.Path != nil {
+		return "save copy reference failed: path/" + e.Path.Error()
+	}
+	return "save copy reference failed: " + e.Tag
+}
+
+// CopyReferenceSave saves the file or folder referenced by copyReference,
+// which may have been got from another account, into path. The metadata of
+// the saved file or folder is returned. Errors are returned as an *APIError
+// holding a *SaveCopyReferenceError.
+func (s *FilesService) CopyReferenceSave(ctx context.Context, copyReference, path string) (Metadata, *http.Response, error) {
+	arg := struct {
+		CopyReference string `json:"copy_reference"`
+		Path          string `json:"path"`
+	}{copyReference, path}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/files/copy_reference/save", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result struct {
+		Metadata metadataValue `json:"metadata"`
+	}
+	resp, err := s.client.do(ctx, (*http.Request)(req), &result, new(SaveCopyReferenceError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result.Metadata.Metadata, resp, nil
+}
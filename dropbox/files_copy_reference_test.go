@@ -0,0 +1,62 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCopyReferenceGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/copy_reference/get", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"metadata":{".tag":"file","name":"a.txt","path_lower":"/a.txt"},"copy_reference":"z1X6ATl6aWtzOGq0c3g5Ng","expires":"2045-05-12T15:50:38Z"}`)
+	})
+
+	ref, _, err := client.Files.CopyReferenceGet(context.Background(), "/a.txt")
+	if err != nil {
+		t.Fatalf("CopyReferenceGet returned unexpected error: %v", err)
+	}
+	if got, want := ref.CopyReference, "z1X6ATl6aWtzOGq0c3g5Ng"; got != want {
+		t.Errorf("CopyReferenceGet CopyReference is %v, want %v", got, want)
+	}
+	if want := time.Date(2045, 5, 12, 15, 50, 38, 0, time.UTC); !ref.Expires.Equal(want) {
+		t.Errorf("CopyReferenceGet Expires is %v, want %v", ref.Expires, want)
+	}
+	if _, ok := ref.Metadata.(*FileMetadata); !ok {
+		t.Errorf("CopyReferenceGet Metadata is %T, want *FileMetadata", ref.Metadata)
+	}
+}
+
+func TestCopyReferenceSave(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/copy_reference/save", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"copy_reference":"z1X6ATl6aWtzOGq0c3g5Ng","path":"/b.txt"}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("copy_reference/save body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"invalid_copy_reference/..","error":{".tag":"invalid_copy_reference"}}`)
+	})
+
+	_, _, err := client.Files.CopyReferenceSave(context.Background(), "z1X6ATl6aWtzOGq0c3g5Ng", "/b.txt")
+	var saveErr *SaveCopyReferenceError
+	if !errors.As(err, &saveErr) || saveErr.Tag != "invalid_copy_reference" {
+		t.Errorf("CopyReferenceSave error is %#v, want an invalid_copy_reference *SaveCopyReferenceError", err)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateFolderError describes why a folder could not be created.
+type CreateFolderError struct {
+	// Either "path" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *WriteError `json:"path,omitempty"`
+}
+
+var createFolderErrorUnion = newUnion("other", map[string]interface{}{
+	"path":  WriteError{},
+	"other": nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *CreateFolderError) UnmarshalJSON(b []byte) error {
+	return createFolderErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the write error, if any.
+func (e *CreateFolderError) Unwrap() error {
+	if e.Path == nil {
+		return nil
+	}
+	return e.Path
+}
+
+func (e *CreateFolderError) Error() string {
+	if e.Tag == "path" && e.Path != nil {
+		return "create folder failed: path/" + e.Path.Error()
+	}
+	return "create folder failed: " + e.Tag
+}
+
+// CreateFolder creates a folder at path. If autorename is true and there is
+// a conflict, the server tries to autorename the folder to avoid it. Errors
+// are returned as an *APIError holding a *CreateFolderError.
+func (s *FilesService) CreateFolder(ctx context.Context, path string, autorename bool) (*FolderMetadata, *http.Response, error) {
+	arg := struct {
+		Path       string `json:"path"`
+		Autorename bool   `json:"autorename"`
+	}{path, autorename}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/files/create_folder_v2", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result struct {
+		Metadata *FolderMetadata `json:"metadata"`
+	}
+	resp, err := s.client.do(ctx, (*http.Request)(req), &result, new(CreateFolderError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result.Metadata, resp, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCreateFolder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/create_folder_v2", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"path":"/Photos","autorename":true}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("request body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"metadata":{"name":"Photos (1)","id":"id:a4ayc_80_OEAAAAAAAAAXz","path_lower":"/photos (1)","path_display":"/Photos (1)"}}`)
+	})
+
+	folder, _, err := client.Files.CreateFolder(context.Background(), "/Photos", true)
+	if err != nil {
+		t.Fatalf("CreateFolder returned unexpected error: %v", err)
+	}
+	if got, want := folder.PathDisplay, "/Photos (1)"; got != want {
+		t.Errorf("CreateFolder PathDisplay is %v, want %v", got, want)
+	}
+}
+
+func TestCreateFolder_conflict(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/create_folder_v2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"path/conflict/folder/..","error":{".tag":"path","path":{".tag":"conflict","conflict":{".tag":"folder"}}}}`)
+	})
+
+	_, _, err := client.Files.CreateFolder(context.Background(), "/Photos", false)
+	var createErr *CreateFolderError
+	if !errors.As(err, &createErr) {
+		t.Fatalf("CreateFolder expected a *CreateFolderError; got %#v", err)
+	}
+	if got, want := createErr.Error(), "create folder failed: path/conflict/folder"; got != want {
+		t.Errorf("CreateFolderError.Error() is %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"net/http"
+)
+
+// DeleteError describes why a file or folder could not be deleted.
+type DeleteError struct {
+	// One of "path_lookup", "path_write", "too_many_write_operations",
+	// "too_many_files" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path_lookup".
+	PathLookup *LookupError `json:"path_lookup,omitempty"`
+
+	// Set when Tag is "path_write".
+	PathWrite *WriteError `json:"path_write,omitempty"`
+}
+
+var deleteErrorUnion = newUnion("other", map[string]interface{}{
+	"path_lookup":               LookupError{},
+	"path_write":                WriteError{},
+	"too_many_write_operations": nil,
+	"too_many_files":            nil,
+	"other":                     nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *DeleteError) UnmarshalJSON(b []byte) error {
+	return deleteErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the lookup or write error, if any.
+func (e *DeleteError) Unwrap() error {
+	switch {
+	case e.PathLookup != nil:
+		return e.PathLookup
+	case e.PathWrite != nil:
+		return e.PathWrite
+	}
+	return nil
+}
+
+func (e *DeleteError) Error() string {
+	if err := e.Unwrap(); err != nil {
+		return "delete failed: " + e.Tag + "/" + err.Error()
+	}
+	return "delete failed: " + e.Tag
+}
+
+// Delete deletes the file or folder at path, including all the folder
+// contents. If parentRev is not empty, the file is only deleted if its
+// current revision is parentRev. The metadata of the deleted entry is
+// returned. Errors are returned as an *APIError holding a *DeleteError.
+func (s *FilesService) Delete(ctx context.Context, path, parentRev string) (Metadata, *http.Response, error) {
+	arg := struct {
+		Path      string `json:"path"`
+		ParentRev string `json:"parent_rev,omitempty"`
+	}{path, parentRev}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/files/delete_v2", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result struct {
+		Metadata metadataValue `json:"metadata"`
+	}
+	resp, err := s.client.do(ctx, (*http.Request)(req), &result, new(DeleteError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result.Metadata.Metadata, resp, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/delete_v2", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"path":"/a.txt","parent_rev":"a1c10ce0dd78"}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("request body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"metadata":{".tag":"file","name":"a.txt","path_lower":"/a.txt","path_display":"/a.txt","rev":"a1c10ce0dd78","size":12}}`)
+	})
+
+	meta, _, err := client.Files.Delete(context.Background(), "/a.txt", "a1c10ce0dd78")
+	if err != nil {
+		t.Fatalf("Delete returned unexpected error: %v", err)
+	}
+	file, ok := meta.(*FileMetadata)
+	if !ok {
+		t.Fatalf("Delete returned %T, want *FileMetadata", meta)
+	}
+	if got, want := file.Rev, "a1c10ce0dd78"; got != want {
+		t.Errorf("Delete Rev is %v, want %v", got, want)
+	}
+}
+
+func TestDelete_notFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/delete_v2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"path_lookup/not_found/..","error":{".tag":"path_lookup","path_lookup":{".tag":"not_found"}}}`)
+	})
+
+	_, _, err := client.Files.Delete(context.Background(), "/missing", "")
+	var deleteErr *DeleteError
+	if !errors.As(err, &deleteErr) {
+		t.Fatalf("Delete expected a *DeleteError; got %#v", err)
+	}
+	if got, want := deleteErr.Error(), "delete failed: path_lookup/not_found"; got != want {
+		t.Errorf("DeleteError.Error() is %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DownloadOptions contains the options used to download a file.
+type DownloadOptions struct {
+	// The revision of the file to download. Defaults to the latest one.
+	Rev string
+
+	// The offset of the first byte to download. If negative, the last
+	// -Offset bytes of the file are downloaded.
+	Offset int64
+
+	// The amount of bytes to download. If zero, the file is downloaded until
+	// its end. It is ignored if Offset is negative.
+	Length int64
+}
+
+func (o *DownloadOptions) rangeHeader() string {
+	switch {
+	case o.Offset < 0:
+		return fmt.Sprintf("bytes=%d", o.Offset)
+	case o.Length > 0:
+		return fmt.Sprintf("bytes=%d-%d", o.Offset, o.Offset+o.Length-1)
+	case o.Offset > 0:
+		return fmt.Sprintf("bytes=%d-", o.Offset)
+	}
+	return ""
+}
+
+// DownloadError is returned by FilesService.Download when the file cannot be
+// downloaded.
+type DownloadError struct {
+	// One of "path", "unsupported_file" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *LookupError `json:"path,omitempty"`
+}
+
+var downloadErrorUnion = newUnion("other", map[string]interface{}{
+	"path":             LookupError{},
+	"unsupported_file": nil,
+	"other":            nil,
+})
+
+// Unwrap returns the lookup error, if any.
+func (e *DownloadError) Unwrap() error {
+	if e.Path == nil {
+		return nil
+	}
+	return e.Path
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *DownloadError) UnmarshalJSON(b []byte) error {
+	return downloadErrorUnion.unmarshal(b, e)
+}
+
+func (e *DownloadError) Error() string {
+	if e.Tag == "path" && e.Path != nil {
+		return "download failed: path/" + e.Path.Error()
+	}
+	return "download failed: " + e.Tag
+}
+
+// Download downloads the file at path using the options in opts, if not nil.
+// The returned body streams the file content and it must be closed by the
+// caller. When a range is requested, the body only contains that range, but
+// the metadata describes the whole file. Errors looking up the file are
+// returned as an *APIError holding a *DownloadError.
+func (s *FilesService) Download(ctx context.Context, path string, opts *DownloadOptions) (*FileMetadata, io.ReadCloser, *http.Response, error) {
+	if opts == nil {
+		opts = &DownloadOptions{}
+	}
+	arg := struct {
+		Path string `json:"path"`
+		Rev  string `json:"rev,omitempty"`
+	}{path, opts.Rev}
+	req, err := s.client.NewDownloadRequest("POST", "2-beta/files/download", &arg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	markIdempotent((*http.Request)(req))
+	if r := opts.rangeHeader(); r != "" {
+		req.Header.Set("Range", r)
+	}
+
+	var meta FileMetadata
+	body, resp, err := s.client.doDownload(ctx, req, &meta, new(DownloadError))
+	if err != nil {
+		return nil, nil, resp, err
+	}
+
+	return &meta, body, resp, nil
+}
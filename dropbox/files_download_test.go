@@ -0,0 +1,83 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDownloadOptions_rangeHeader(t *testing.T) {
+	tests := []struct {
+		opts DownloadOptions
+		want string
+	}{
+		{DownloadOptions{}, ""},
+		{DownloadOptions{Offset: 100}, "bytes=100-"},
+		{DownloadOptions{Offset: 100, Length: 50}, "bytes=100-149"},
+		{DownloadOptions{Length: 50}, "bytes=0-49"},
+		{DownloadOptions{Offset: -512, Length: 50}, "bytes=-512"},
+	}
+	for _, test := range tests {
+		if got := test.opts.rangeHeader(); got != test.want {
+			t.Errorf("%+v.rangeHeader() is %q, want %q", test.opts, got, test.want)
+		}
+	}
+}
+
+func TestDownload_rev(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/download", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Dropbox-API-Arg"), `{"path":"/a.txt","rev":"a1c10ce0dd78"}`; got != want {
+			t.Errorf("Dropbox-API-Arg Header is %v, want %v", got, want)
+		}
+		if got, want := r.Header.Get("Range"), "bytes=-10"; got != want {
+			t.Errorf("Range Header is %v, want %v", got, want)
+		}
+		w.Header().Set("Dropbox-API-Result", `{"rev":"a1c10ce0dd78"}`)
+		w.WriteHeader(http.StatusPartialContent)
+	})
+
+	meta, body, _, err := client.Files.Download(context.Background(), "/a.txt", &DownloadOptions{Rev: "a1c10ce0dd78", Offset: -10})
+	if err != nil {
+		t.Fatalf("Download returned unexpected error: %v", err)
+	}
+	body.Close()
+	if got, want := meta.Rev, "a1c10ce0dd78"; got != want {
+		t.Errorf("Download FileMetadata.Rev is %v, want %v", got, want)
+	}
+}
+
+func TestDownload_notFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"path/not_found/..","error":{".tag":"path","path":{".tag":"not_found"}}}`)
+	})
+
+	_, body, _, err := client.Files.Download(context.Background(), "/missing.txt", nil)
+	if body != nil {
+		t.Error("Download returned a body on error")
+	}
+	var downloadErr *DownloadError
+	if !errors.As(err, &downloadErr) {
+		t.Fatalf("Download expected a *DownloadError; got %#v", err)
+	}
+	if got, want := downloadErr.Error(), "download failed: path/not_found"; got != want {
+		t.Errorf("DownloadError.Error() is %v, want %v", got, want)
+	}
+	var lookupErr *LookupError
+	if !errors.As(err, &lookupErr) || lookupErr.Tag != "not_found" {
+		t.Errorf("Download returned %#v, want a not_found *LookupError", err)
+	}
+}
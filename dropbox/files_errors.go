@@ -0,0 +1,92 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+// WriteError describes why a write operation on a path failed.
+type WriteError struct {
+	// One of "malformed_path", "conflict", "no_write_permission",
+	// "insufficient_space", "disallowed_name", "team_folder",
+	// "too_many_write_operations" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "malformed_path", if any explanation is available.
+	MalformedPath string `json:"malformed_path,omitempty"`
+
+	// Set when Tag is "conflict".
+	Conflict *WriteConflictError `json:"conflict,omitempty"`
+}
+
+var writeErrorUnion = newUnion("other", map[string]interface{}{
+	"malformed_path":            "",
+	"conflict":                  WriteConflictError{},
+	"no_write_permission":       nil,
+	"insufficient_space":        nil,
+	"disallowed_name":           nil,
+	"team_folder":               nil,
+	"operation_suppressed":      nil,
+	"too_many_write_operations": nil,
+	"other":                     nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *WriteError) UnmarshalJSON(b []byte) error {
+	return writeErrorUnion.unmarshal(b, e)
+}
+
+func (e *WriteError) Error() string {
+	if e.Tag == "conflict" && e.Conflict != nil {
+		return e.Tag + "/" + e.Conflict.Tag
+	}
+	return e.Tag
+}
+
+// WriteConflictError describes what is already in the path of a write
+// operation.
+type WriteConflictError struct {
+	// One of "file", "folder", "file_ancestor" or "other".
+	Tag string `json:".tag"`
+}
+
+var writeConflictErrorUnion = newUnion("other", map[string]interface{}{
+	"file":          nil,
+	"folder":        nil,
+	"file_ancestor": nil,
+	"other":         nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *WriteConflictError) UnmarshalJSON(b []byte) error {
+	return writeConflictErrorUnion.unmarshal(b, e)
+}
+
+// LookupError describes why a path could not be looked up.
+type LookupError struct {
+	// One of "malformed_path", "not_found", "not_file", "not_folder",
+	// "restricted_content", "unsupported_content_type", "locked" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "malformed_path", if any explanation is available.
+	MalformedPath string `json:"malformed_path,omitempty"`
+}
+
+var lookupErrorUnion = newUnion("other", map[string]interface{}{
+	"malformed_path":           "",
+	"not_found":                nil,
+	"not_file":                 nil,
+	"not_folder":               nil,
+	"restricted_content":       nil,
+	"unsupported_content_type": nil,
+	"locked":                   nil,
+	"other":                    nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *LookupError) UnmarshalJSON(b []byte) error {
+	return lookupErrorUnion.unmarshal(b, e)
+}
+
+func (e *LookupError) Error() string {
+	return e.Tag
+}
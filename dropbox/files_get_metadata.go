@@ -0,0 +1,85 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetMetadataOptions contains the options used to get the metadata of a
+// file or folder.
+type GetMetadataOptions struct {
+	// If true, FileMetadata.MediaInfo is set for photo and video files.
+	IncludeMediaInfo bool `json:"include_media_info"`
+
+	// If true, a *DeletedMetadata is returned if the file or folder has been
+	// deleted, instead of a not_found error.
+	IncludeDeleted bool `json:"include_deleted"`
+
+	// If true, FileMetadata.HasExplicitSharedMembers is set.
+	IncludeHasExplicitSharedMembers bool `json:"include_has_explicit_shared_members"`
+}
+
+// GetMetadataError describes why the metadata of a path could not be
+// retrieved.
+type GetMetadataError struct {
+	// Either "path" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *LookupError `json:"path,omitempty"`
+}
+
+var getMetadataErrorUnion = newUnion("other", map[string]interface{}{
+	"path":  LookupError{},
+	"other": nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *GetMetadataError) UnmarshalJSON(b []byte) error {
+	return getMetadataErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the lookup error, if any.
+func (e *GetMetadataError) Unwrap() error {
+	if e.Path == nil {
+		return nil
+	}
+	return e.Path
+}
+
+func (e *GetMetadataError) Error() string {
+	if e.Tag == "path" && e.Path != nil {
+		return "get metadata failed: path/" + e.Path.Error()
+	}
+	return "get metadata failed: " + e.Tag
+}
+
+// GetMetadata returns the metadata of the file or folder at path, using the
+// options in opts, if not nil. Errors are returned as an *APIError holding a
+// *GetMetadataError.
+func (s *FilesService) GetMetadata(ctx context.Context, path string, opts *GetMetadataOptions) (Metadata, *http.Response, error) {
+	arg := struct {
+		Path string `json:"path"`
+		GetMetadataOptions
+	}{Path: path}
+	if opts != nil {
+		arg.GetMetadataOptions = *opts
+	}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/files/get_metadata", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	var meta metadataValue
+	resp, err := s.client.do(ctx, (*http.Request)(req), &meta, new(GetMetadataError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return meta.Metadata, resp, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetMetadata(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/get_metadata", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"path":"/old.txt","include_media_info":false,"include_deleted":true,"include_has_explicit_shared_members":false}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("request body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{".tag":"deleted","name":"old.txt","path_lower":"/old.txt","path_display":"/old.txt"}`)
+	})
+
+	meta, _, err := client.Files.GetMetadata(context.Background(), "/old.txt", &GetMetadataOptions{IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("GetMetadata returned unexpected error: %v", err)
+	}
+	deleted, ok := meta.(*DeletedMetadata)
+	if !ok {
+		t.Fatalf("GetMetadata returned %T, want *DeletedMetadata", meta)
+	}
+	if got, want := deleted.PathDisplay, "/old.txt"; got != want {
+		t.Errorf("GetMetadata PathDisplay is %v, want %v", got, want)
+	}
+}
+
+func TestGetMetadata_notFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/get_metadata", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"path/not_found/..","error":{".tag":"path","path":{".tag":"not_found"}}}`)
+	})
+
+	_, _, err := client.Files.GetMetadata(context.Background(), "/missing", nil)
+	var metaErr *GetMetadataError
+	if !errors.As(err, &metaErr) {
+		t.Fatalf("GetMetadata expected a *GetMetadataError; got %#v", err)
+	}
+	var lookupErr *LookupError
+	if !errors.As(err, &lookupErr) || lookupErr.Tag != "not_found" {
+		t.Errorf("GetMetadata expected a not_found *LookupError; got %#v", err)
+	}
+}
@@ -4,62 +4,206 @@
 
 package dropbox
 
-import "net/http"
+import (
+	"context"
+	"errors"
+	"net/http"
+)
 
-type Entry struct {
-	Name string `json:"name"`
+// ErrEmptyCursor is reported by the iterators resuming a listing from an
+// empty cursor.
+var ErrEmptyCursor = errors.New("dropbox: empty cursor")
+
+// ListFolderOptions contains the options used to list a folder.
+type ListFolderOptions struct {
+	// If true, the listing includes the contents of all subfolders.
+	Recursive bool `json:"recursive"`
+
+	// If true, FileMetadata.MediaInfo is set for photo and video files.
+	IncludeMediaInfo bool `json:"include_media_info"`
+
+	// If true, the listing includes entries for files and folders that have
+	// been deleted.
+	IncludeDeleted bool `json:"include_deleted"`
+
+	// If false, the listing does not include the contents of mounted
+	// folders, such as shared folders. Defaults to true.
+	IncludeMountedFolders *bool `json:"include_mounted_folders,omitempty"`
+
+	// The maximum number of entries returned in each page. This is only a
+	// hint, and the server may return more or less entries. Zero means no
+	// limit.
+	Limit uint32 `json:"limit,omitempty"`
+}
+
+// ListFolderError describes why a folder could not be listed.
+type ListFolderError struct {
+	// One of "path", "reset" or "other". "reset" is only returned when
+	// continuing a listing, and it means that the cursor has been
+	// invalidated and the folder must be listed again from scratch.
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *LookupError `json:"path,omitempty"`
+}
+
+var listFolderErrorUnion = newUnion("other", map[string]interface{}{
+	"path":  LookupError{},
+	"reset": nil,
+	"other": nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ListFolderError) UnmarshalJSON(b []byte) error {
+	return listFolderErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the lookup error, if any.
+func (e *ListFolderError) Unwrap() error {
+	if e.Path == nil {
+		return nil
+	}
+	return e.Path
+}
+
+func (e *ListFolderError) Error() string {
+	if e.Tag == "path" && e.Path != nil {
+		return "list folder failed: path/" + e.Path.Error()
+	}
+	return "list folder failed: " + e.Tag
 }
 
-type listResponse struct {
-	Entries []Entry           `json:"entries"`
-	Footer  listReponseFooter `json:"footer"`
+type listFolderResult struct {
+	Entries metadataList `json:"entries"`
+	Cursor  string       `json:"cursor"`
+	HasMore bool         `json:"has_more"`
 }
 
-type listReponseFooter struct {
-	Cursor  string `json:"cursor,omitempty"`
-	HasMore bool   `json:"has_more"`
+// ListFolderIterator iterates over the entries of a folder, fetching them
+// page by page as needed. Use it as follows:
+//
+//	it := c.Files.ListFolderIterator(ctx, "/photos", nil)
+//	for it.Next() {
+//		entry := it.Metadata()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ListFolderIterator struct {
+	ctx     context.Context
+	s       *FilesService
+	path    string
+	opts    ListFolderOptions
+	cursor  string
+	hasMore bool
+	entries []Metadata
+	current Metadata
+	resp    *http.Response
+	err     error
 }
 
-func (s *FilesService) ListFolder(path string) (entries []Entry, resp *http.Response, err error) {
+// ListFolderIterator returns an iterator over the entries of the folder at
+// path, listed using the options in opts, if not nil. Errors are returned
+// as an *APIError holding a *ListFolderError.
+func (s *FilesService) ListFolderIterator(ctx context.Context, path string, opts *ListFolderOptions) *ListFolderIterator {
 	if path == "/" {
 		path = ""
 	}
+	it := &ListFolderIterator{ctx: ctx, s: s, path: path, hasMore: true}
+	if opts != nil {
+		it.opts = *opts
+	}
+	return it
+}
 
-	cursor := ""
-	for {
-		var ents []Entry
-		ents, cursor, resp, err = s.listFolder(path, cursor)
-		if err != nil {
-			return
-		}
-		entries = append(entries, ents...)
-		if cursor == "" {
-			return
+// ListFolderContinue returns an iterator over the entries listed after
+// cursor, which must be a cursor returned by ListFolderIterator.Cursor.
+// It can be used to resume a listing, or to get the changes made to a folder
+// since its listing. A "reset" *ListFolderError is returned if the cursor is
+// no longer valid, and ErrEmptyCursor if it is empty.
+func (s *FilesService) ListFolderContinue(ctx context.Context, cursor string) *ListFolderIterator {
+	if cursor == "" {
+		return &ListFolderIterator{err: ErrEmptyCursor}
+	}
+	return &ListFolderIterator{ctx: ctx, s: s, cursor: cursor, hasMore: true}
+}
+
+// Next advances the iterator to the next entry, which is then available
+// through the Metadata method. It returns false when there are no more
+// entries or an error happens.
+func (it *ListFolderIterator) Next() bool {
+	for len(it.entries) == 0 {
+		if it.err != nil || !it.hasMore {
+			it.current = nil
+			return false
 		}
+		it.fetch()
 	}
+	it.current, it.entries = it.entries[0], it.entries[1:]
+	return true
+}
+
+// Metadata returns the current entry.
+func (it *ListFolderIterator) Metadata() Metadata {
+	return it.current
 }
 
-func (s *FilesService) listFolder(path, cursor string) (entries []Entry, nextCursor string, resp *http.Response, err error) {
-	params := struct {
-		Path   string `json:"path"`
-		Cursor string `json:"cursor,omitempty"`
-	}{
-		Path:   path,
-		Cursor: cursor,
+// Cursor returns the cursor of the last page fetched. Continuing from it
+// returns the entries listed after that page, so it should be stored once
+// all its entries have been processed, for example when Next returns false.
+// Then, it can be used to resume the listing or to get the later changes to
+// the folder using ListFolderContinue.
+func (it *ListFolderIterator) Cursor() string {
+	return it.cursor
+}
+
+// Err returns the error, if any, that happened while iterating.
+func (it *ListFolderIterator) Err() error {
+	return it.err
+}
+
+// Response returns the HTTP response of the last page fetched.
+func (it *ListFolderIterator) Response() *http.Response {
+	return it.resp
+}
+
+func (it *ListFolderIterator) fetch() {
+	var req *RPCRequest
+	if it.cursor == "" {
+		arg := struct {
+			Path string `json:"path"`
+			ListFolderOptions
+		}{it.path, it.opts}
+		req, it.err = it.s.client.NewRPCRequest("POST", "2-beta/files/list_folder", &arg)
+	} else {
+		arg := struct {
+			Cursor string `json:"cursor"`
+		}{it.cursor}
+		req, it.err = it.s.client.NewRPCRequest("POST", "2-beta/files/list_folder/continue", &arg)
 	}
-	req, err := s.client.NewRPCRequest("POST", "2-beta/files/list_folder", &params)
-	if err != nil {
+	if it.err != nil {
 		return
 	}
+	markIdempotent((*http.Request)(req))
 
-	var respData listResponse
-	resp, err = s.client.DoRPC(req, &respData)
-	if err != nil {
+	var result listFolderResult
+	it.resp, it.err = it.s.client.do(it.ctx, (*http.Request)(req), &result, new(ListFolderError))
+	if it.err != nil {
 		return
 	}
-	if respData.Footer.HasMore {
-		nextCursor = respData.Footer.Cursor
+	it.entries = result.Entries
+	it.cursor = result.Cursor
+	it.hasMore = result.HasMore && result.Cursor != ""
+}
+
+// ListFolder returns the metadata of every entry in the folder at path. Use
+// ListFolderIterator to list large folders without keeping all their entries
+// in memory.
+func (s *FilesService) ListFolder(ctx context.Context, path string) (entries []Metadata, resp *http.Response, err error) {
+	it := s.ListFolderIterator(ctx, path, nil)
+	for it.Next() {
+		entries = append(entries, it.Metadata())
 	}
-	entries = respData.Entries
-	return
+	return entries, it.Response(), it.Err()
 }
@@ -0,0 +1,134 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListFolderIterator_options(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/list_folder", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"path":"/a","recursive":true,"include_media_info":true,"include_deleted":false,"include_mounted_folders":false,"limit":10}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("list_folder body is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"entries":[{".tag":"file","name":"a.jpg","media_info":{".tag":"metadata","metadata":{".tag":"photo","dimensions":{"height":10,"width":20}}}}],"cursor":"c1","has_more":false}`)
+	})
+
+	it := client.Files.ListFolderIterator(context.Background(), "/a", &ListFolderOptions{
+		Recursive:             true,
+		IncludeMediaInfo:      true,
+		IncludeMountedFolders: Bool(false),
+		Limit:                 10,
+	})
+	if !it.Next() {
+		t.Fatalf("ListFolderIterator.Next returned false: %v", it.Err())
+	}
+	file := it.Metadata().(*FileMetadata)
+	if file.MediaInfo == nil || file.MediaInfo.Metadata == nil || file.MediaInfo.Metadata.Dimensions.Width != 20 {
+		t.Errorf("ListFolderIterator entry MediaInfo is %#v, want photo dimensions", file.MediaInfo)
+	}
+	if it.Next() {
+		t.Error("ListFolderIterator.Next returned true after the last entry")
+	}
+	if got, want := it.Cursor(), "c1"; got != want {
+		t.Errorf("ListFolderIterator.Cursor is %v, want %v", got, want)
+	}
+}
+
+func TestListFolderContinue_reset(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/list_folder/continue", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"reset/..","error":{".tag":"reset"}}`)
+	})
+
+	it := client.Files.ListFolderContinue(context.Background(), "stale")
+	if it.Next() {
+		t.Fatal("ListFolderContinue.Next returned true on error")
+	}
+	var listErr *ListFolderError
+	if !errors.As(it.Err(), &listErr) || listErr.Tag != "reset" {
+		t.Errorf("ListFolderContinue.Err is %#v, want a reset *ListFolderError", it.Err())
+	}
+}
+
+func TestListFolderIterator_cancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/list_folder", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"entries":[{".tag":"file","name":"a"}],"cursor":"c1","has_more":true}`)
+	})
+	mux.HandleFunc("/2-beta/files/list_folder/continue", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("list_folder/continue called after cancelling the context")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := client.Files.ListFolderIterator(ctx, "", nil)
+	if !it.Next() {
+		t.Fatalf("ListFolderIterator.Next returned false: %v", it.Err())
+	}
+	cancel()
+	if it.Next() {
+		t.Error("ListFolderIterator.Next returned true after cancelling the context")
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("ListFolderIterator.Err is %v, want %v", it.Err(), context.Canceled)
+	}
+}
+
+func TestListFolderIterator_hasMoreWithoutCursor(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/2-beta/files/list_folder", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"entries":[{".tag":"file","name":"a"}],"cursor":"","has_more":true}`)
+	})
+
+	it := client.Files.ListFolderIterator(context.Background(), "", nil)
+	n := 0
+	for it.Next() && n < 10 {
+		n++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("ListFolderIterator returned unexpected error: %v", err)
+	}
+	if n != 1 || calls != 1 {
+		t.Errorf("ListFolderIterator listed %v entries in %v calls, want 1 entry in 1 call", n, calls)
+	}
+}
+
+func TestListFolderContinue_emptyCursor(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/list_folder", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("list_folder called with an empty cursor")
+	})
+
+	it := client.Files.ListFolderContinue(context.Background(), "")
+	if it.Next() {
+		t.Fatal("ListFolderContinue.Next returned true with an empty cursor")
+	}
+	if !errors.Is(it.Err(), ErrEmptyCursor) {
+		t.Errorf("ListFolderContinue.Err is %v, want %v", it.Err(), ErrEmptyCursor)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ListFolderLongpollResult is the result of waiting for changes in a folder.
+type ListFolderLongpollResult struct {
+	// If true, there are changes available. Get them using
+	// ListFolderContinue.
+	Changes bool
+
+	// If not zero, the time the client should wait before calling
+	// ListFolderLongpoll again.
+	Backoff time.Duration
+}
+
+// GetLatestCursor returns a cursor for the current state of the folder at
+// path, without listing it. Opts, if not nil, must be the same options
+// used to list the folder later. Errors are returned as an *APIError holding
+// a *ListFolderError.
+func (s *FilesService) GetLatestCursor(ctx context.Context, path string, opts *ListFolderOptions) (string, *http.Response, error) {
+	if path == "/" {
+		path = ""
+	}
+	arg := struct {
+		Path string `json:"path"`
+		ListFolderOptions
+	}{Path: path}
+	if opts != nil {
+		arg.ListFolderOptions = *opts
+	}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/files/list_folder/get_latest_cursor", &arg)
+	if err != nil {
+		return "", nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	var result struct {
+		Cursor string `json:"cursor"`
+	}
+	resp, err := s.client.do(ctx, (*http.Request)(req), &result, new(ListFolderError))
+	if err != nil {
+		return "", resp, err
+	}
+
+	return result.Cursor, resp, nil
+}
+
+// ListFolderLongpoll waits for changes in the folder listed by cursor, up to
+// timeout, which is rounded to seconds and must be between 30 seconds and 8
+// minutes. If timeout is zero, the server waits 30 seconds. The request is
+// sent to the NotifyURL of the Client. A "reset" *ListFolderError is returned
+// if the cursor is no longer valid.
+func (s *FilesService) ListFolderLongpoll(ctx context.Context, cursor string, timeout time.Duration) (*ListFolderLongpollResult, *http.Response, error) {
+	arg := struct {
+		Cursor  string `json:"cursor"`
+		Timeout int64  `json:"timeout,omitempty"`
+	}{cursor, int64(timeout / time.Second)}
+	u := s.client.NotifyURL.ResolveReference(&url.URL{Path: "2-beta/files/list_folder/longpoll"})
+	req, err := s.client.NewRPCRequest("POST", u.String(), &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	var result struct {
+		Changes bool  `json:"changes"`
+		Backoff int64 `json:"backoff"`
+	}
+	resp, err := s.client.do(ctx, (*http.Request)(req), &result, new(ListFolderError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &ListFolderLongpollResult{
+		Changes: result.Changes,
+		Backoff: time.Duration(result.Backoff) * time.Second,
+	}, resp, nil
+}
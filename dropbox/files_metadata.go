@@ -0,0 +1,277 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Metadata is the metadata of an entry in a user's Dropbox. It is one of
+// *FileMetadata, *FolderMetadata or *DeletedMetadata.
+type Metadata interface {
+	// GetName returns the last component of the path.
+	GetName() string
+
+	// GetPathLower returns the lowercased full path.
+	GetPathLower() string
+
+	// GetPathDisplay returns the cased path to be used for display purposes
+	// only.
+	GetPathDisplay() string
+}
+
+// FileMetadata contains the metadata of a file.
+type FileMetadata struct {
+	// The last component of the path (including extension).
+	Name string `json:"name"`
+
+	// A unique identifier for the file.
+	ID string `json:"id"`
+
+	// The lowercased full path in the user's Dropbox.
+	PathLower string `json:"path_lower"`
+
+	// The cased path to be used for display purposes only.
+	PathDisplay string `json:"path_display"`
+
+	// ID of the shared folder that contains this file, if any.
+	ParentSharedFolderID string `json:"parent_shared_folder_id,omitempty"`
+
+	// For files, this is the modification time set by the desktop client when
+	// the file was added to Dropbox.
+	ClientModified time.Time `json:"client_modified"`
+
+	// The last time the file was modified on Dropbox.
+	ServerModified time.Time `json:"server_modified"`
+
+	// A unique identifier for the current revision of a file.
+	Rev string `json:"rev"`
+
+	// The file size in bytes.
+	Size uint64 `json:"size"`
+
+	// A hash of the file content, see ContentHash.
+	ContentHash string `json:"content_hash,omitempty"`
+
+	// Set if this file is contained in a shared folder.
+	SharingInfo *FileSharingInfo `json:"sharing_info,omitempty"`
+
+	// Additional information if the file is a photo or video. Only set when
+	// requested.
+	MediaInfo *MediaInfo `json:"media_info,omitempty"`
+
+	// If false, the file cannot be downloaded.
+	IsDownloadable bool `json:"is_downloadable"`
+
+	// If true, the file has explicit members with access.
+	HasExplicitSharedMembers bool `json:"has_explicit_shared_members,omitempty"`
+}
+
+// FileSharingInfo contains sharing information of a file.
+type FileSharingInfo struct {
+	// True if the file or folder is inside a read-only shared folder.
+	ReadOnly bool `json:"read_only"`
+
+	// ID of shared folder that holds this file.
+	ParentSharedFolderID string `json:"parent_shared_folder_id"`
+
+	// The last user who modified the file.
+	ModifiedBy string `json:"modified_by,omitempty"`
+}
+
+// MediaInfo contains the media information of a photo or video file.
+type MediaInfo struct {
+	// Either "pending", if the media information is still being extracted
+	// from the file, or "metadata".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "metadata".
+	Metadata *MediaMetadata `json:"metadata,omitempty"`
+}
+
+var mediaInfoUnion = newUnion("pending", map[string]interface{}{
+	"pending":  nil,
+	"metadata": MediaMetadata{},
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *MediaInfo) UnmarshalJSON(b []byte) error {
+	return mediaInfoUnion.unmarshal(b, m)
+}
+
+// MediaMetadata contains the metadata of a photo or video.
+type MediaMetadata struct {
+	// Either "photo" or "video".
+	Tag string `json:".tag"`
+
+	// Dimension of the photo or video, if any.
+	Dimensions *Dimensions `json:"dimensions,omitempty"`
+
+	// The GPS coordinate of the photo or video, if any.
+	Location *GPSCoordinates `json:"location,omitempty"`
+
+	// The time when the photo or video is taken, if any.
+	TimeTaken *time.Time `json:"time_taken,omitempty"`
+
+	// The duration of the video in milliseconds.
+	Duration uint64 `json:"duration,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. MediaMetadata has subtypes, so
+// it is not inlined when it is a union variant.
+func (m *MediaMetadata) UnmarshalJSON(b []byte) error {
+	type mediaMetadata MediaMetadata
+	return json.Unmarshal(b, (*mediaMetadata)(m))
+}
+
+// Dimensions contains the dimensions of a photo or video.
+type Dimensions struct {
+	Height uint64 `json:"height"`
+	Width  uint64 `json:"width"`
+}
+
+// GPSCoordinates contains a geographic position.
+type GPSCoordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// FolderMetadata contains the metadata of a folder.
+type FolderMetadata struct {
+	// The last component of the path.
+	Name string `json:"name"`
+
+	// A unique identifier for the folder.
+	ID string `json:"id"`
+
+	// The lowercased full path in the user's Dropbox.
+	PathLower string `json:"path_lower"`
+
+	// The cased path to be used for display purposes only.
+	PathDisplay string `json:"path_display"`
+
+	// ID of the shared folder that contains this folder, if any.
+	ParentSharedFolderID string `json:"parent_shared_folder_id,omitempty"`
+
+	// If this folder is a shared folder mount point, the ID of the shared
+	// folder mounted at this location.
+	SharedFolderID string `json:"shared_folder_id,omitempty"`
+
+	// Set if the folder is contained in a shared folder or is a shared folder
+	// mount point.
+	SharingInfo *FolderSharingInfo `json:"sharing_info,omitempty"`
+}
+
+// FolderSharingInfo contains sharing information of a folder.
+type FolderSharingInfo struct {
+	// True if the file or folder is inside a read-only shared folder.
+	ReadOnly bool `json:"read_only"`
+
+	// ID of the shared folder that contains this folder, if any.
+	ParentSharedFolderID string `json:"parent_shared_folder_id,omitempty"`
+
+	// If this folder is a shared folder mount point, the ID of the shared
+	// folder mounted at this location.
+	SharedFolderID string `json:"shared_folder_id,omitempty"`
+
+	// If true, the user only has access to some of the folder contents.
+	TraverseOnly bool `json:"traverse_only"`
+
+	// If true, the user has no access to this folder.
+	NoAccess bool `json:"no_access"`
+}
+
+// DeletedMetadata contains the metadata of a deleted file or folder.
+type DeletedMetadata struct {
+	// The last component of the path.
+	Name string `json:"name"`
+
+	// The lowercased full path in the user's Dropbox.
+	PathLower string `json:"path_lower"`
+
+	// The cased path to be used for display purposes only.
+	PathDisplay string `json:"path_display"`
+
+	// ID of the shared folder that contained this entry, if any.
+	ParentSharedFolderID string `json:"parent_shared_folder_id,omitempty"`
+}
+
+// GetName returns the last component of the path.
+func (m *FileMetadata) GetName() string { return m.Name }
+
+// GetPathLower returns the lowercased full path.
+func (m *FileMetadata) GetPathLower() string { return m.PathLower }
+
+// GetPathDisplay returns the cased path to be used for display purposes only.
+func (m *FileMetadata) GetPathDisplay() string { return m.PathDisplay }
+
+// GetName returns the last component of the path.
+func (m *FolderMetadata) GetName() string { return m.Name }
+
+// GetPathLower returns the lowercased full path.
+func (m *FolderMetadata) GetPathLower() string { return m.PathLower }
+
+// GetPathDisplay returns the cased path to be used for display purposes only.
+func (m *FolderMetadata) GetPathDisplay() string { return m.PathDisplay }
+
+// GetName returns the last component of the path.
+func (m *DeletedMetadata) GetName() string { return m.Name }
+
+// GetPathLower returns the lowercased full path.
+func (m *DeletedMetadata) GetPathLower() string { return m.PathLower }
+
+// GetPathDisplay returns the cased path to be used for display purposes only.
+func (m *DeletedMetadata) GetPathDisplay() string { return m.PathDisplay }
+
+var metadataUnion = newUnion("", map[string]interface{}{
+	"file":    FileMetadata{},
+	"folder":  FolderMetadata{},
+	"deleted": DeletedMetadata{},
+})
+
+// decodeMetadata decodes a JSON encoded Metadata using its ".tag" field.
+func decodeMetadata(b []byte) (Metadata, error) {
+	_, m, err := metadataUnion.decode(b)
+	if err != nil {
+		return nil, err
+	}
+	return m.(Metadata), nil
+}
+
+// metadataValue is a Metadata that can be JSON decoded.
+type metadataValue struct {
+	Metadata
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *metadataValue) UnmarshalJSON(b []byte) error {
+	m, err := decodeMetadata(b)
+	if err != nil {
+		return err
+	}
+	v.Metadata = m
+	return nil
+}
+
+// metadataList is a list of Metadata that can be JSON decoded.
+type metadataList []Metadata
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *metadataList) UnmarshalJSON(b []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(b, &raws); err != nil {
+		return err
+	}
+	*l = make(metadataList, len(raws))
+	for i, raw := range raws {
+		m, err := decodeMetadata(raw)
+		if err != nil {
+			return err
+		}
+		(*l)[i] = m
+	}
+	return nil
+}
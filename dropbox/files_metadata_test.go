@@ -0,0 +1,51 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMetadataList_UnmarshalJSON(t *testing.T) {
+	blob := `[
+		{".tag":"file","name":"a.txt","id":"id:a","path_lower":"/a.txt","path_display":"/a.txt","client_modified":"2015-05-12T15:50:38Z","server_modified":"2015-05-12T15:50:38Z","rev":"a1c10ce0dd78","size":7212,"content_hash":"e3b0c442"},
+		{".tag":"folder","name":"B","id":"id:b","path_lower":"/b","path_display":"/B","shared_folder_id":"84528192421"},
+		{".tag":"deleted","name":"c.txt","path_lower":"/c.txt","path_display":"/c.txt"}
+	]`
+	var list metadataList
+	if err := json.Unmarshal([]byte(blob), &list); err != nil {
+		t.Fatalf("json.Unmarshal returned unexpected error: %v", err)
+	}
+
+	modified := time.Date(2015, 5, 12, 15, 50, 38, 0, time.UTC)
+	want := metadataList{
+		&FileMetadata{
+			Name:           "a.txt",
+			ID:             "id:a",
+			PathLower:      "/a.txt",
+			PathDisplay:    "/a.txt",
+			ClientModified: modified,
+			ServerModified: modified,
+			Rev:            "a1c10ce0dd78",
+			Size:           7212,
+			ContentHash:    "e3b0c442",
+		},
+		&FolderMetadata{Name: "B", ID: "id:b", PathLower: "/b", PathDisplay: "/B", SharedFolderID: "84528192421"},
+		&DeletedMetadata{Name: "c.txt", PathLower: "/c.txt", PathDisplay: "/c.txt"},
+	}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("json.Unmarshal(metadataList) is %#v, want %#v", list, want)
+	}
+}
+
+func TestMetadataList_UnmarshalJSON_unknownTag(t *testing.T) {
+	var list metadataList
+	if err := json.Unmarshal([]byte(`[{".tag":"symlink","name":"a"}]`), &list); err == nil {
+		t.Error("json.Unmarshal(metadataList) expected error to be returned")
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"net/http"
+)
+
+// RelocationOptions contains the options used to copy or move a file or
+// folder.
+type RelocationOptions struct {
+	// If true, shared folders may be copied or moved. Only used by Copy and
+	// Move.
+	AllowSharedFolder bool `json:"allow_shared_folder"`
+
+	// If true and there is a conflict, the server tries to autorename the
+	// destination to avoid it.
+	Autorename bool `json:"autorename"`
+
+	// If true, contents may be moved even if it results in an ownership
+	// transfer. Ignored by Copy and CopyBatch.
+	AllowOwnershipTransfer bool `json:"allow_ownership_transfer"`
+}
+
+// RelocationPath is a source and destination pair of a copy or move.
+type RelocationPath struct {
+	// The path of the file or folder to copy or move.
+	FromPath string `json:"from_path"`
+
+	// The destination path.
+	ToPath string `json:"to_path"`
+}
+
+// RelocationError describes why a file or folder could not be copied or
+// moved.
+type RelocationError struct {
+	// One of "from_lookup", "from_write", "to", "cant_copy_shared_folder",
+	// "cant_nest_shared_folder", "cant_move_folder_into_itself",
+	// "too_many_files", "duplicated_or_nested_paths",
+	// "cant_transfer_ownership", "insufficient_quota", "internal_error",
+	// "cant_move_shared_folder" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "from_lookup".
+	FromLookup *LookupError `json:"from_lookup,omitempty"`
+
+	// Set when Tag is "from_write".
+	FromWrite *WriteError `json:"from_write,omitempty"`
+
+	// Set when Tag is "to".
+	To *WriteError `json:"to,omitempty"`
+}
+
+var relocationErrorUnion = newUnion("other", map[string]interface{}{
+	"from_lookup":                  LookupError{},
+	"from_write":                   WriteError{},
+	"to":                           WriteError{},
+	"cant_copy_shared_folder":      nil,
+	"cant_nest_shared_folder":      nil,
+	"cant_move_folder_into_itself": nil,
+	"too_many_files":               nil,
+	"duplicated_or_nested_paths":   nil,
+	"cant_transfer_ownership":      nil,
+	"insufficient_quota":           nil,
+	"internal_error":               nil,
+	"cant_move_shared_folder":      nil,
+	"other":                        nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *RelocationError) UnmarshalJSON(b []byte) error {
+	return relocationErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the lookup or write error, if any.
+func (e *RelocationError) Unwrap() error {
+	switch {
+	case e.FromLookup != nil:
+		return e.FromLookup
+	case e.FromWrite != nil:
+		return e.FromWrite
+	case e.To != nil:
+		return e.To
+	}
+	return nil
+}
+
+func (e *RelocationError) Error() string {
+	if err := e.Unwrap(); err != nil {
+		return "relocation failed: " + e.Tag + "/" + err.Error()
+	}
+	return "relocation failed: " + e.Tag
+}
+
+// Copy copies the file or folder at from to the path to, using the options
+// in opts, if not nil. The metadata of the copy is returned. Errors are
+// returned as an *APIError holding a *RelocationError.
+func (s *FilesService) Copy(ctx context.Context, from, to string, opts *RelocationOptions) (Metadata, *http.Response, error) {
+	return s.relocate(ctx, "2-beta/files/copy_v2", from, to, opts)
+}
+
+// Move moves the file or folder at from to the path to, using the options
+// in opts, if not nil. The metadata of the moved entry is returned. Errors
+// are returned as an *APIError holding a *RelocationError.
+func (s *FilesService) Move(ctx context.Context, from, to string, opts *RelocationOptions) (Metadata, *http.Response, error) {
+	return s.relocate(ctx, "2-beta/files/move_v2", from, to, opts)
+}
+
+func (s *FilesService) relocate(ctx context.Context, urlStr, from, to string, opts *RelocationOptions) (Metadata, *http.Response, error) {
+	arg := struct {
+		RelocationPath
+		RelocationOptions
+	}{RelocationPath: RelocationPath{from, to}}
+	if opts != nil {
+		arg.RelocationOptions = *opts
+	}
+	req, err := s.client.NewRPCRequest("POST", urlStr, &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result struct {
+		Metadata metadataValue `json:"metadata"`
+	}
+	resp, err := s.client.do(ctx, (*http.Request)(req), &result, new(RelocationError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result.Metadata.Metadata, resp, nil
+}
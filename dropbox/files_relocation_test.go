@@ -0,0 +1,58 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCopy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/copy_v2", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"from_path":"/a","to_path":"/b","allow_shared_folder":true,"autorename":true,"allow_ownership_transfer":false}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("copy_v2 body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"metadata":{".tag":"folder","name":"b","path_lower":"/b","path_display":"/b"}}`)
+	})
+
+	meta, _, err := client.Files.Copy(context.Background(), "/a", "/b", &RelocationOptions{AllowSharedFolder: true, Autorename: true})
+	if err != nil {
+		t.Fatalf("Copy returned unexpected error: %v", err)
+	}
+	if _, ok := meta.(*FolderMetadata); !ok {
+		t.Errorf("Copy returned %T, want *FolderMetadata", meta)
+	}
+}
+
+func TestMove_conflict(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/move_v2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"to/conflict/file/..","error":{".tag":"to","to":{".tag":"conflict","conflict":{".tag":"file"}}}}`)
+	})
+
+	_, _, err := client.Files.Move(context.Background(), "/a", "/b", nil)
+	var relocationErr *RelocationError
+	if !errors.As(err, &relocationErr) {
+		t.Fatalf("Move expected a *RelocationError; got %#v", err)
+	}
+	if got, want := relocationErr.Error(), "relocation failed: to/conflict/file"; got != want {
+		t.Errorf("RelocationError.Error() is %v, want %v", got, want)
+	}
+}
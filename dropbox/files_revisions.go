@@ -0,0 +1,163 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// ListRevisionsOptions contains the options used to list the revisions of a
+// file.
+type ListRevisionsOptions struct {
+	// Either "path" or "id". In "path" mode, the revisions of the file at the
+	// given path are returned, even if the file was replaced or moved. In
+	// "id" mode, the revisions of the file with the given ID are returned,
+	// wherever it has been. Defaults to "path".
+	Mode string `json:"mode,omitempty"`
+
+	// The maximum number of revisions returned, up to 100. Defaults to 10.
+	Limit uint64 `json:"limit,omitempty"`
+}
+
+// ListRevisionsResult contains the revisions of a file.
+type ListRevisionsResult struct {
+	// If the file is currently deleted. Only set in "path" mode.
+	IsDeleted bool `json:"is_deleted"`
+
+	// The time of the deletion, if the file is deleted.
+	ServerDeleted *time.Time `json:"server_deleted,omitempty"`
+
+	// The revisions of the file, sorted from newest to oldest.
+	Entries []FileMetadata `json:"entries"`
+}
+
+// ListRevisionsError describes why the revisions of a file could not be
+// listed.
+type ListRevisionsError struct {
+	// Either "path" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *LookupError `json:"path,omitempty"`
+}
+
+var listRevisionsErrorUnion = newUnion("other", map[string]interface{}{
+	"path":  LookupError{},
+	"other": nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ListRevisionsError) UnmarshalJSON(b []byte) error {
+	return listRevisionsErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the lookup error, if any.
+func (e *ListRevisionsError) Unwrap() error {
+	if e.Path == nil {
+		return nil
+	}
+	return e.Path
+}
+
+func (e *ListRevisionsError) Error() string {
+	if e.Tag == "path" && e.Path != nil {
+		return "list revisions failed: path/" + e.Path.Error()
+	}
+	return "list revisions failed: " + e.Tag
+}
+
+// ListRevisions returns the revisions of the file at path, which may also be
+// a file ID in "id" mode, using the options in opts, if not nil. Errors are
+// returned as an *APIError holding a *ListRevisionsError.
+func (s *FilesService) ListRevisions(ctx context.Context, path string, opts *ListRevisionsOptions) (*ListRevisionsResult, *http.Response, error) {
+	arg := struct {
+		Path string `json:"path"`
+		ListRevisionsOptions
+	}{Path: path}
+	if opts != nil {
+		arg.ListRevisionsOptions = *opts
+	}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/files/list_revisions", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	result := new(ListRevisionsResult)
+	resp, err := s.client.do(ctx, (*http.Request)(req), result, new(ListRevisionsError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
+// RestoreError describes why a file could not be restored.
+type RestoreError struct {
+	// One of "path_lookup", "path_write", "invalid_revision", "in_progress"
+	// or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path_lookup".
+	PathLookup *LookupError `json:"path_lookup,omitempty"`
+
+	// Set when Tag is "path_write".
+	PathWrite *WriteError `json:"path_write,omitempty"`
+}
+
+var restoreErrorUnion = newUnion("other", map[string]interface{}{
+	"path_lookup":      LookupError{},
+	"path_write":       WriteError{},
+	"invalid_revision": nil,
+	"in_progress":      nil,
+	"other":            nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *RestoreError) UnmarshalJSON(b []byte) error {
+	return restoreErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the lookup or write error, if any.
+func (e *RestoreError) Unwrap() error {
+	switch {
+	case e.PathLookup != nil:
+		return e.PathLookup
+	case e.PathWrite != nil:
+		return e.PathWrite
+	}
+	return nil
+}
+
+func (e *RestoreError) Error() string {
+	if err := e.Unwrap(); err != nil {
+		return "restore failed: " + e.Tag + "/" + err.Error()
+	}
+	return "restore failed: " + e.Tag
+}
+
+// Restore restores the file at path to the revision rev, and returns the
+// metadata of the restored file. Errors are returned as an *APIError holding
+// a *RestoreError.
+func (s *FilesService) Restore(ctx context.Context, path, rev string) (*FileMetadata, *http.Response, error) {
+	arg := struct {
+		Path string `json:"path"`
+		Rev  string `json:"rev"`
+	}{path, rev}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/files/restore", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meta := new(FileMetadata)
+	resp, err := s.client.do(ctx, (*http.Request)(req), meta, new(RestoreError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return meta, resp, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListRevisions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/list_revisions", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"path":"/a.txt","mode":"path","limit":2}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("list_revisions body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"is_deleted":true,"server_deleted":"2015-05-12T15:50:38Z","entries":[`+
+			`{"name":"a.txt","path_lower":"/a.txt","rev":"2"},{"name":"a.txt","path_lower":"/a.txt","rev":"1"}]}`)
+	})
+
+	result, _, err := client.Files.ListRevisions(context.Background(), "/a.txt", &ListRevisionsOptions{Mode: "path", Limit: 2})
+	if err != nil {
+		t.Fatalf("ListRevisions returned unexpected error: %v", err)
+	}
+	if !result.IsDeleted {
+		t.Error("ListRevisions IsDeleted is false, want true")
+	}
+	if want := time.Date(2015, 5, 12, 15, 50, 38, 0, time.UTC); result.ServerDeleted == nil || !result.ServerDeleted.Equal(want) {
+		t.Errorf("ListRevisions ServerDeleted is %v, want %v", result.ServerDeleted, want)
+	}
+	if len(result.Entries) != 2 || result.Entries[0].Rev != "2" || result.Entries[1].Rev != "1" {
+		t.Errorf("ListRevisions Entries is %+v, want revisions 2 and 1", result.Entries)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/restore", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"path":"/a.txt","rev":"1"}`; got != want {
+			t.Errorf("restore body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name":"a.txt","path_lower":"/a.txt","rev":"3"}`)
+	})
+
+	meta, _, err := client.Files.Restore(context.Background(), "/a.txt", "1")
+	if err != nil {
+		t.Fatalf("Restore returned unexpected error: %v", err)
+	}
+	if got, want := meta.Rev, "3"; got != want {
+		t.Errorf("Restore Rev is %v, want %v", got, want)
+	}
+}
+
+func TestRestore_invalidRevision(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/restore", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"invalid_revision/..","error":{".tag":"invalid_revision"}}`)
+	})
+
+	_, _, err := client.Files.Restore(context.Background(), "/a.txt", "bad")
+	var restoreErr *RestoreError
+	if !errors.As(err, &restoreErr) || restoreErr.Tag != "invalid_revision" {
+		t.Errorf("Restore error is %#v, want an invalid_revision *RestoreError", err)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// SaveURLError describes why a URL could not be saved.
+type SaveURLError struct {
+	// One of "path", "download_failed", "invalid_url", "not_found" or
+	// "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *WriteError `json:"path,omitempty"`
+}
+
+var saveURLErrorUnion = newUnion("other", map[string]interface{}{
+	"path":            WriteError{},
+	"download_failed": nil,
+	"invalid_url":     nil,
+	"not_found":       nil,
+	"other":           nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SaveURLError) UnmarshalJSON(b []byte) error {
+	return saveURLErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the write error, if any.
+func (e *SaveURLError) Unwrap() error {
+	if e.Path == nil {
+		return nil
+	}
+	return e.Path
+}
+
+func (e *SaveURLError) Error() string {
+	if e.Tag == "path" && e.Path != nil {
+		return "save url failed: path/" + e.Path.Error()
+	}
+	return "save url failed: " + e.Tag
+}
+
+// SaveURLJobStatus is the status of a save URL job.
+type SaveURLJobStatus struct {
+	// One of "async_job_id", "in_progress", "complete", "failed" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "async_job_id".
+	AsyncJobID string `json:"async_job_id,omitempty"`
+
+	// The metadata of the saved file. Set when Tag is "complete".
+	Complete *FileMetadata `json:"complete,omitempty"`
+
+	// Set when Tag is "failed".
+	Failed *SaveURLError `json:"failed,omitempty"`
+}
+
+var saveURLJobStatusUnion = newUnion("other", map[string]interface{}{
+	"async_job_id": "",
+	"in_progress":  nil,
+	"complete":     FileMetadata{},
+	"failed":       SaveURLError{},
+	"other":        nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SaveURLJobStatus) UnmarshalJSON(b []byte) error {
+	return saveURLJobStatusUnion.unmarshal(b, s)
+}
+
+// Pending reports whether the job is still running.
+func (s *SaveURLJobStatus) Pending() bool {
+	return s.Tag == "async_job_id" || s.Tag == "in_progress"
+}
+
+// result returns the metadata of the saved file, or the error saving it.
+func (s *SaveURLJobStatus) result() (*FileMetadata, error) {
+	switch {
+	case s.Complete != nil:
+		return s.Complete, nil
+	case s.Failed != nil:
+		return nil, s.Failed
+	}
+	return nil, errors.New("dropbox: save url finished with status " + s.Tag)
+}
+
+// SaveURL starts saving the file at url into path. Dropbox downloads the
+// file in the background; the returned status holds the ID of the job, which
+// can be checked with SaveURLCheckJobStatus or awaited with WaitSaveURLJob.
+// Errors starting the job are returned as an *APIError holding a
+// *SaveURLError.
+func (s *FilesService) SaveURL(ctx context.Context, path, url string) (*SaveURLJobStatus, *http.Response, error) {
+	arg := struct {
+		Path string `json:"path"`
+		URL  string `json:"url"`
+	}{path, url}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/files/save_url", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status := new(SaveURLJobStatus)
+	resp, err := s.client.do(ctx, (*http.Request)(req), status, new(SaveURLError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return status, resp, nil
+}
+
+// SaveURLCheckJobStatus returns the status of the save URL job jobID. Errors
+// checking the status are returned as an *APIError holding a *PollError.
+func (s *FilesService) SaveURLCheckJobStatus(ctx context.Context, jobID string) (*SaveURLJobStatus, *http.Response, error) {
+	status := new(SaveURLJobStatus)
+	resp, err := s.client.CheckAsyncJob(ctx, "2-beta/files/save_url/check_job_status", jobID, status)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return status, resp, nil
+}
+
+// WaitSaveURLJob waits until the save URL job jobID finishes, checking its
+// status as configured by the PollPolicy of the client, and returns the
+// metadata of the saved file. If the job fails, a *SaveURLError is returned.
+func (s *FilesService) WaitSaveURLJob(ctx context.Context, jobID string) (*FileMetadata, *http.Response, error) {
+	status := &SaveURLJobStatus{Tag: "async_job_id", AsyncJobID: jobID}
+	resp, err := s.client.WaitAsyncJob(ctx, "2-beta/files/save_url/check_job_status", jobID, status, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	meta, err := status.result()
+	return meta, resp, err
+}
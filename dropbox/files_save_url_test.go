@@ -0,0 +1,81 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSaveURL(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/save_url", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"path":"/a.zip","url":"https://example.com/a.zip"}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("save_url body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{".tag":"async_job_id","async_job_id":"job-1"}`)
+	})
+	checks := 0
+	mux.HandleFunc("/2-beta/files/save_url/check_job_status", func(w http.ResponseWriter, r *http.Request) {
+		checks++
+		w.Header().Set("Content-Type", "application/json")
+		if checks == 1 {
+			fmt.Fprint(w, `{".tag":"in_progress"}`)
+			return
+		}
+		fmt.Fprint(w, `{".tag":"complete","name":"a.zip","path_lower":"/a.zip","size":10}`)
+	})
+
+	ctx := context.Background()
+	status, _, err := client.Files.SaveURL(ctx, "/a.zip", "https://example.com/a.zip")
+	if err != nil {
+		t.Fatalf("SaveURL returned unexpected error: %v", err)
+	}
+	if got, want := status.AsyncJobID, "job-1"; got != want {
+		t.Fatalf("SaveURL AsyncJobID is %v, want %v", got, want)
+	}
+
+	check, _, err := client.Files.SaveURLCheckJobStatus(ctx, status.AsyncJobID)
+	if err != nil {
+		t.Fatalf("SaveURLCheckJobStatus returned unexpected error: %v", err)
+	}
+	if got, want := check.Tag, "in_progress"; got != want {
+		t.Errorf("SaveURLCheckJobStatus Tag is %v, want %v", got, want)
+	}
+
+	meta, _, err := client.Files.WaitSaveURLJob(ctx, status.AsyncJobID)
+	if err != nil {
+		t.Fatalf("WaitSaveURLJob returned unexpected error: %v", err)
+	}
+	if got, want := meta.Size, uint64(10); got != want {
+		t.Errorf("WaitSaveURLJob Size is %v, want %v", got, want)
+	}
+}
+
+func TestWaitSaveURLJob_failed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/save_url/check_job_status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{".tag":"failed","failed":{".tag":"download_failed"}}`)
+	})
+
+	_, _, err := client.Files.WaitSaveURLJob(context.Background(), "job-1")
+	var saveErr *SaveURLError
+	if !errors.As(err, &saveErr) || saveErr.Tag != "download_failed" {
+		t.Errorf("WaitSaveURLJob error is %#v, want a download_failed *SaveURLError", err)
+	}
+}
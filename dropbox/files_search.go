@@ -0,0 +1,270 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// SearchOptions contains the options used to search files and folders.
+type SearchOptions struct {
+	// The path of the folder to search in. Defaults to the whole Dropbox.
+	Path string `json:"path,omitempty"`
+
+	// The maximum number of results returned in each page, up to 1000.
+	// Defaults to 100.
+	MaxResults uint64 `json:"max_results,omitempty"`
+
+	// Either "relevance" or "last_modified_time". Defaults to "relevance".
+	OrderBy string `json:"order_by,omitempty"`
+
+	// Either "active" or "deleted". Defaults to "active".
+	FileStatus string `json:"file_status,omitempty"`
+
+	// If true, only file names are searched, instead of file names and
+	// contents.
+	FilenameOnly bool `json:"filename_only"`
+
+	// If not empty, only files with these extensions are returned.
+	FileExtensions []string `json:"file_extensions,omitempty"`
+
+	// If not empty, only files of these categories are returned. Categories
+	// are "image", "document", "pdf", "spreadsheet", "presentation",
+	// "audio", "video", "folder", "paper" and "others".
+	FileCategories []string `json:"file_categories,omitempty"`
+
+	// If true, the spans of the file names matching the query are returned
+	// in SearchMatch.HighlightSpans.
+	IncludeHighlights bool `json:"-"`
+}
+
+// SearchMatch is a file or folder matching a search.
+type SearchMatch struct {
+	// The metadata of the matched file or folder.
+	Metadata Metadata
+
+	// One of "filename", "file_content", "filename_and_content",
+	// "image_content" or "other".
+	MatchType string
+
+	// The spans of the name of the file or folder, and whether they match
+	// the query. Only set if highlights were requested.
+	HighlightSpans []HighlightSpan
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *SearchMatch) UnmarshalJSON(b []byte) error {
+	var match struct {
+		Metadata  metadataV2 `json:"metadata"`
+		MatchType struct {
+			Tag string `json:".tag"`
+		} `json:"match_type"`
+		HighlightSpans []HighlightSpan `json:"highlight_spans"`
+	}
+	if err := json.Unmarshal(b, &match); err != nil {
+		return err
+	}
+	*m = SearchMatch{
+		MatchType:      match.MatchType.Tag,
+		HighlightSpans: match.HighlightSpans,
+	}
+	if match.Metadata.Metadata != nil {
+		m.Metadata = match.Metadata.Metadata.Metadata
+	}
+	return nil
+}
+
+// HighlightSpan is a span of a matched name.
+type HighlightSpan struct {
+	// The text of the span.
+	HighlightStr string `json:"highlight_str"`
+
+	// If the span matches the query.
+	IsHighlighted bool `json:"is_highlighted"`
+}
+
+// metadataV2 is a Metadata wrapped in a union, to allow extending it.
+type metadataV2 struct {
+	// Either "metadata" or "other".
+	Tag      string         `json:".tag"`
+	Metadata *metadataValue `json:"metadata"`
+}
+
+var metadataV2Union = newUnion("other", map[string]interface{}{
+	"metadata": metadataValue{},
+	"other":    nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *metadataV2) UnmarshalJSON(b []byte) error {
+	return metadataV2Union.unmarshal(b, m)
+}
+
+// SearchError describes why a search failed.
+type SearchError struct {
+	// One of "path", "invalid_argument", "internal_error" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *LookupError `json:"path,omitempty"`
+
+	// May be set when Tag is "invalid_argument".
+	InvalidArgument string `json:"invalid_argument,omitempty"`
+}
+
+var searchErrorUnion = newUnion("other", map[string]interface{}{
+	"path":             LookupError{},
+	"invalid_argument": "",
+	"internal_error":   nil,
+	"other":            nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SearchError) UnmarshalJSON(b []byte) error {
+	return searchErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the lookup error, if any.
+func (e *SearchError) Unwrap() error {
+	if e.Path == nil {
+		return nil
+	}
+	return e.Path
+}
+
+func (e *SearchError) Error() string {
+	switch {
+	case e.Path != nil:
+		return "search failed: path/" + e.Path.Error()
+	case e.InvalidArgument != "":
+		return "search failed: invalid_argument: " + e.InvalidArgument
+	}
+	return "search failed: " + e.Tag
+}
+
+type searchResult struct {
+	Matches []SearchMatch `json:"matches"`
+	Cursor  string        `json:"cursor"`
+	HasMore bool          `json:"has_more"`
+}
+
+// SearchIterator iterates over the matches of a search, fetching them page
+// by page as needed. Use it as follows:
+//
+//	it := c.Files.Search(ctx, "report", nil)
+//	for it.Next() {
+//		match := it.Match()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type SearchIterator struct {
+	ctx     context.Context
+	s       *FilesService
+	query   string
+	opts    SearchOptions
+	cursor  string
+	hasMore bool
+	matches []SearchMatch
+	current *SearchMatch
+	resp    *http.Response
+	err     error
+}
+
+// Search returns an iterator over the files and folders matching query,
+// searched using the options in opts, if not nil. Errors are returned as an
+// *APIError holding a *SearchError.
+func (s *FilesService) Search(ctx context.Context, query string, opts *SearchOptions) *SearchIterator {
+	it := &SearchIterator{ctx: ctx, s: s, query: query, hasMore: true}
+	if opts != nil {
+		it.opts = *opts
+	}
+	return it
+}
+
+// SearchContinue returns an iterator over the matches found after cursor,
+// which must be a cursor returned by SearchIterator.Cursor. ErrEmptyCursor is
+// returned if cursor is empty.
+func (s *FilesService) SearchContinue(ctx context.Context, cursor string) *SearchIterator {
+	if cursor == "" {
+		return &SearchIterator{err: ErrEmptyCursor}
+	}
+	return &SearchIterator{ctx: ctx, s: s, cursor: cursor, hasMore: true}
+}
+
+// Next advances the iterator to the next match, which is then available
+// through the Match method. It returns false when there are no more matches
+// or an error happens.
+func (it *SearchIterator) Next() bool {
+	for len(it.matches) == 0 {
+		if it.err != nil || !it.hasMore {
+			it.current = nil
+			return false
+		}
+		it.fetch()
+	}
+	it.current, it.matches = &it.matches[0], it.matches[1:]
+	return true
+}
+
+// Match returns the current match.
+func (it *SearchIterator) Match() *SearchMatch {
+	return it.current
+}
+
+// Cursor returns the cursor of the last page fetched, which can be used to
+// resume the search using SearchContinue.
+func (it *SearchIterator) Cursor() string {
+	return it.cursor
+}
+
+// Err returns the error, if any, that happened while iterating.
+func (it *SearchIterator) Err() error {
+	return it.err
+}
+
+// Response returns the HTTP response of the last page fetched.
+func (it *SearchIterator) Response() *http.Response {
+	return it.resp
+}
+
+func (it *SearchIterator) fetch() {
+	var req *RPCRequest
+	if it.cursor == "" {
+		type matchFieldOptions struct {
+			IncludeHighlights bool `json:"include_highlights"`
+		}
+		arg := struct {
+			Query             string             `json:"query"`
+			Options           SearchOptions      `json:"options"`
+			MatchFieldOptions *matchFieldOptions `json:"match_field_options,omitempty"`
+		}{Query: it.query, Options: it.opts}
+		if it.opts.IncludeHighlights {
+			arg.MatchFieldOptions = &matchFieldOptions{true}
+		}
+		req, it.err = it.s.client.NewRPCRequest("POST", "2-beta/files/search_v2", &arg)
+	} else {
+		arg := struct {
+			Cursor string `json:"cursor"`
+		}{it.cursor}
+		req, it.err = it.s.client.NewRPCRequest("POST", "2-beta/files/search/continue_v2", &arg)
+	}
+	if it.err != nil {
+		return
+	}
+	markIdempotent((*http.Request)(req))
+
+	var result searchResult
+	it.resp, it.err = it.s.client.do(it.ctx, (*http.Request)(req), &result, new(SearchError))
+	if it.err != nil {
+		return
+	}
+	it.matches = result.Matches
+	it.cursor = result.Cursor
+	it.hasMore = result.HasMore && result.Cursor != ""
+}
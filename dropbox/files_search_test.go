@@ -0,0 +1,127 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/search_v2", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"query":"report","options":{"path":"/docs","max_results":1,"filename_only":true,"file_extensions":["pdf"]},"match_field_options":{"include_highlights":true}}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("search_v2 body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"matches":[{"metadata":{".tag":"metadata","metadata":{".tag":"file","name":"report.pdf","path_lower":"/docs/report.pdf"}},`+
+			`"match_type":{".tag":"filename"},"highlight_spans":[{"highlight_str":"report","is_highlighted":true},{"highlight_str":".pdf","is_highlighted":false}]}],`+
+			`"has_more":true,"cursor":"c1"}`)
+	})
+	mux.HandleFunc("/2-beta/files/search/continue_v2", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"cursor":"c1"}`; got != want {
+			t.Errorf("search/continue_v2 body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"matches":[{"metadata":{".tag":"metadata","metadata":{".tag":"folder","name":"reports","path_lower":"/docs/reports"}},`+
+			`"match_type":{".tag":"filename"}}],"has_more":false,"cursor":"c2"}`)
+	})
+
+	it := client.Files.Search(context.Background(), "report", &SearchOptions{
+		Path:              "/docs",
+		MaxResults:        1,
+		FilenameOnly:      true,
+		FileExtensions:    []string{"pdf"},
+		IncludeHighlights: true,
+	})
+	var paths []string
+	for it.Next() {
+		paths = append(paths, it.Match().Metadata.GetPathLower())
+		if len(paths) == 1 {
+			match := it.Match()
+			if got, want := match.MatchType, "filename"; got != want {
+				t.Errorf("Search MatchType is %v, want %v", got, want)
+			}
+			if len(match.HighlightSpans) != 2 || !match.HighlightSpans[0].IsHighlighted || match.HighlightSpans[0].HighlightStr != "report" {
+				t.Errorf("Search HighlightSpans is %+v, want report highlighted", match.HighlightSpans)
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Search returned unexpected error: %v", err)
+	}
+	if got, want := strings.Join(paths, ","), "/docs/report.pdf,/docs/reports"; got != want {
+		t.Errorf("Search matches are %v, want %v", got, want)
+	}
+	if got, want := it.Cursor(), "c2"; got != want {
+		t.Errorf("Search Cursor is %v, want %v", got, want)
+	}
+}
+
+func TestSearch_invalidArgument(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/search_v2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"invalid_argument/..","error":{".tag":"invalid_argument","invalid_argument":"query is empty"}}`)
+	})
+
+	it := client.Files.Search(context.Background(), "", nil)
+	if it.Next() {
+		t.Fatal("Search.Next returned true on error")
+	}
+	var searchErr *SearchError
+	if !errors.As(it.Err(), &searchErr) {
+		t.Fatalf("Search.Err is %#v, want a *SearchError", it.Err())
+	}
+	if got, want := searchErr.Error(), "search failed: invalid_argument: query is empty"; got != want {
+		t.Errorf("SearchError.Error() is %v, want %v", got, want)
+	}
+}
+
+func TestSearch_hasMoreWithoutCursor(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/2-beta/files/search_v2", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"matches":[{"metadata":{".tag":"metadata","metadata":{".tag":"file","name":"a"}}}],"has_more":true,"cursor":""}`)
+	})
+
+	it := client.Files.Search(context.Background(), "a", nil)
+	n := 0
+	for it.Next() && n < 10 {
+		n++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Search returned unexpected error: %v", err)
+	}
+	if n != 1 || calls != 1 {
+		t.Errorf("Search returned %v matches in %v calls, want 1 match in 1 call", n, calls)
+	}
+}
+
+func TestSearchContinue_emptyCursor(t *testing.T) {
+	it := NewClient(nil).Files.SearchContinue(context.Background(), "")
+	if it.Next() {
+		t.Fatal("SearchContinue.Next returned true with an empty cursor")
+	}
+	if !errors.Is(it.Err(), ErrEmptyCursor) {
+		t.Errorf("SearchContinue.Err is %v, want %v", it.Err(), ErrEmptyCursor)
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// maxThumbnailBatchEntries is the maximum number of entries accepted by the
+// get_thumbnail_batch endpoint.
+const maxThumbnailBatchEntries = 25
+
+// ThumbnailOptions contains the options used to get the thumbnail of an
+// image.
+type ThumbnailOptions struct {
+	// Either "jpeg" or "png". Defaults to "jpeg".
+	Format string `json:"format,omitempty"`
+
+	// One of "w32h32", "w64h64", "w128h128", "w256h256", "w480h320",
+	// "w640h480", "w960h640", "w1024h768" or "w2048h1536". Defaults to
+	// "w64h64".
+	Size string `json:"size,omitempty"`
+
+	// One of "strict", which scales the image down to fit within the size,
+	// "bestfit", which scales it down to fit within the size or its
+	// transpose, or "fitone_bestfit", which scales it down to completely
+	// cover the size or its transpose, filling one of its dimensions. No mode
+	// crops the image. Defaults to "strict".
+	Mode string `json:"mode,omitempty"`
+}
+
+// ThumbnailError describes why the thumbnail of a file could not be got.
+type ThumbnailError struct {
+	// One of "path", "unsupported_extension", "unsupported_image",
+	// "conversion_error" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *LookupError `json:"path,omitempty"`
+}
+
+var thumbnailErrorUnion = newUnion("other", map[string]interface{}{
+	"path":                  LookupError{},
+	"unsupported_extension": nil,
+	"unsupported_image":     nil,
+	"conversion_error":      nil,
+	"other":                 nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ThumbnailError) UnmarshalJSON(b []byte) error {
+	return thumbnailErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the lookup error, if any.
+func (e *ThumbnailError) Unwrap() error {
+	if e.Path == nil {
+		return nil
+	}
+	return e.Path
+}
+
+func (e *ThumbnailError) Error() string {
+	if e.Tag == "path" && e.Path != nil {
+		return "thumbnail failed: path/" + e.Path.Error()
+	}
+	return "thumbnail failed: " + e.Tag
+}
+
+// GetThumbnail downloads a thumbnail of the image at path, using the options
+// in opts, if not nil. The returned body streams the thumbnail and it must be
+// closed by the caller. Errors are returned as an *APIError holding a
+// *ThumbnailError.
+func (s *FilesService) GetThumbnail(ctx context.Context, path string, opts *ThumbnailOptions) (*FileMetadata, io.ReadCloser, *http.Response, error) {
+	arg := struct {
+		Path string `json:"path"`
+		ThumbnailOptions
+	}{Path: path}
+	if opts != nil {
+		arg.ThumbnailOptions = *opts
+	}
+	req, err := s.client.NewDownloadRequest("POST", "2-beta/files/get_thumbnail", &arg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	var meta FileMetadata
+	body, resp, err := s.client.doDownload(ctx, req, &meta, new(ThumbnailError))
+	if err != nil {
+		return nil, nil, resp, err
+	}
+
+	return &meta, body, resp, nil
+}
+
+// PreviewError describes why the preview of a file could not be got.
+type PreviewError struct {
+	// One of "path", "in_progress", "unsupported_extension",
+	// "unsupported_content" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *LookupError `json:"path,omitempty"`
+}
+
+var previewErrorUnion = newUnion("other", map[string]interface{}{
+	"path":                  LookupError{},
+	"in_progress":           nil,
+	"unsupported_extension": nil,
+	"unsupported_content":   nil,
+	"other":                 nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *PreviewError) UnmarshalJSON(b []byte) error {
+	return previewErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the lookup error, if any.
+func (e *PreviewError) Unwrap() error {
+	if e.Path == nil {
+		return nil
+	}
+	return e.Path
+}
+
+func (e *PreviewError) Error() string {
+	if e.Tag == "path" && e.Path != nil {
+		return "preview failed: path/" + e.Path.Error()
+	}
+	return "preview failed: " + e.Tag
+}
+
+// GetPreview downloads a preview of the file at path, at the revision rev
+// if not empty. Previews are PDF files for documents and HTML files for
+// spreadsheets. The returned body streams the preview and it must be closed
+// by the caller. Errors are returned as an *APIError holding a
+// *PreviewError.
+func (s *FilesService) GetPreview(ctx context.Context, path, rev string) (*FileMetadata, io.ReadCloser, *http.Response, error) {
+	arg := struct {
+		Path string `json:"path"`
+		Rev  string `json:"rev,omitempty"`
+	}{path, rev}
+	req, err := s.client.NewDownloadRequest("POST", "2-beta/files/get_preview", &arg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	var meta FileMetadata
+	body, resp, err := s.client.doDownload(ctx, req, &meta, new(PreviewError))
+	if err != nil {
+		return nil, nil, resp, err
+	}
+
+	return &meta, body, resp, nil
+}
+
+// ThumbnailBatchEntry is an image whose thumbnail is got by
+// GetThumbnailBatch.
+type ThumbnailBatchEntry struct {
+	// The path of the image.
+	Path string `json:"path"`
+
+	ThumbnailOptions
+}
+
+// ThumbnailBatchResult is the result of getting the thumbnail of a
+// ThumbnailBatchEntry.
+type ThumbnailBatchResult struct {
+	// The metadata of the image, if its thumbnail was got.
+	Metadata *FileMetadata
+
+	// The thumbnail, if it was got.
+	Thumbnail []byte
+
+	// The error getting the thumbnail, if any.
+	Err error
+}
+
+// GetThumbnailBatchError describes why a thumbnail batch failed as a whole.
+type GetThumbnailBatchError struct {
+	// Either "too_many_files" or "other".
+	Tag string `json:".tag"`
+}
+
+var getThumbnailBatchErrorUnion = newUnion("other", map[string]interface{}{
+	"too_many_files": nil,
+	"other":          nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *GetThumbnailBatchError) UnmarshalJSON(b []byte) error {
+	return getThumbnailBatchErrorUnion.unmarshal(b, e)
+}
+
+func (e *GetThumbnailBatchError) Error() string {
+	return "thumbnail batch failed: " + e.Tag
+}
+
+type thumbnailBatchResultEntry struct {
+	// One of "success", "failure" or "other".
+	Tag     string                    `json:".tag"`
+	Success *thumbnailBatchResultData `json:"success"`
+	Failure *ThumbnailError           `json:"failure"`
+}
+
+type thumbnailBatchResultData struct {
+	Metadata  FileMetadata `json:"metadata"`
+	Thumbnail []byte       `json:"thumbnail"`
+}
+
+var thumbnailBatchResultEntryUnion = newUnion("other", map[string]interface{}{
+	"success": thumbnailBatchResultData{},
+	"failure": ThumbnailError{},
+	"other":   nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *thumbnailBatchResultEntry) UnmarshalJSON(b []byte) error {
+	return thumbnailBatchResultEntryUnion.unmarshal(b, e)
+}
+
+// GetThumbnailBatch gets the thumbnails of up to 25 images with a single
+// request, sent to the ContentURL of the Client. The results are returned in
+// the same order as the entries. A failure getting a thumbnail is reported
+// in its result, while errors of the batch as a whole are returned as an
+// *APIError holding a *GetThumbnailBatchError.
+func (s *FilesService) GetThumbnailBatch(ctx context.Context, entries []ThumbnailBatchEntry) ([]ThumbnailBatchResult, *http.Response, error) {
+	if len(entries) > maxThumbnailBatchEntries {
+		return nil, nil, errors.New("dropbox: too many entries in thumbnail batch")
+	}
+
+	arg := struct {
+		Entries []ThumbnailBatchEntry `json:"entries"`
+	}{entries}
+	u := s.client.ContentURL.ResolveReference(&url.URL{Path: "2-beta/files/get_thumbnail_batch"})
+	req, err := s.client.NewRPCRequest("POST", u.String(), &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	var result struct {
+		Entries []thumbnailBatchResultEntry `json:"entries"`
+	}
+	resp, err := s.client.do(ctx, (*http.Request)(req), &result, new(GetThumbnailBatchError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	results := make([]ThumbnailBatchResult, len(result.Entries))
+	for i, e := range result.Entries {
+		switch {
+		case e.Success != nil:
+			results[i].Metadata = &e.Success.Metadata
+			results[i].Thumbnail = e.Success.Thumbnail
+		case e.Failure != nil:
+			results[i].Err = e.Failure
+		default:
+			results[i].Err = &ThumbnailError{Tag: e.Tag}
+		}
+	}
+
+	return results, resp, nil
+}
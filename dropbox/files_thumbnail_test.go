@@ -0,0 +1,109 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetThumbnail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/get_thumbnail", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Dropbox-API-Arg"), `{"path":"/a.jpg","format":"png","size":"w128h128","mode":"bestfit"}`; got != want {
+			t.Errorf("Dropbox-API-Arg Header is %v, want %v", got, want)
+		}
+		w.Header().Set("Dropbox-API-Result", `{"name":"a.jpg","path_lower":"/a.jpg"}`)
+		fmt.Fprint(w, "png data")
+	})
+
+	meta, body, _, err := client.Files.GetThumbnail(context.Background(), "/a.jpg", &ThumbnailOptions{Format: "png", Size: "w128h128", Mode: "bestfit"})
+	if err != nil {
+		t.Fatalf("GetThumbnail returned unexpected error: %v", err)
+	}
+	defer body.Close()
+	data, _ := ioutil.ReadAll(body)
+	if got, want := string(data), "png data"; got != want {
+		t.Errorf("GetThumbnail body is %v, want %v", got, want)
+	}
+	if got, want := meta.PathLower, "/a.jpg"; got != want {
+		t.Errorf("GetThumbnail PathLower is %v, want %v", got, want)
+	}
+}
+
+func TestGetPreview_unsupported(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/get_preview", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Dropbox-API-Arg"), `{"path":"/a.bin","rev":"1"}`; got != want {
+			t.Errorf("Dropbox-API-Arg Header is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"unsupported_extension/..","error":{".tag":"unsupported_extension"}}`)
+	})
+
+	_, body, _, err := client.Files.GetPreview(context.Background(), "/a.bin", "1")
+	if body != nil {
+		t.Error("GetPreview returned a body on error")
+	}
+	var previewErr *PreviewError
+	if !errors.As(err, &previewErr) || previewErr.Tag != "unsupported_extension" {
+		t.Errorf("GetPreview error is %#v, want an unsupported_extension *PreviewError", err)
+	}
+}
+
+func TestGetThumbnailBatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/get_thumbnail_batch", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"entries":[{"path":"/a.jpg","size":"w32h32"},{"path":"/b.txt"}]}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("get_thumbnail_batch body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"entries":[`+
+			`{".tag":"success","metadata":{"name":"a.jpg","path_lower":"/a.jpg"},"thumbnail":"anBlZyBkYXRh"},`+
+			`{".tag":"failure","failure":{".tag":"unsupported_extension"}}]}`)
+	})
+
+	results, _, err := client.Files.GetThumbnailBatch(context.Background(), []ThumbnailBatchEntry{
+		{Path: "/a.jpg", ThumbnailOptions: ThumbnailOptions{Size: "w32h32"}},
+		{Path: "/b.txt"},
+	})
+	if err != nil {
+		t.Fatalf("GetThumbnailBatch returned unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("GetThumbnailBatch returned %v results, want 2", len(results))
+	}
+	if got, want := string(results[0].Thumbnail), "jpeg data"; got != want {
+		t.Errorf("GetThumbnailBatch results[0].Thumbnail is %v, want %v", got, want)
+	}
+	if results[0].Metadata == nil || results[0].Metadata.PathLower != "/a.jpg" {
+		t.Errorf("GetThumbnailBatch results[0].Metadata is %+v, want /a.jpg", results[0].Metadata)
+	}
+	var thumbErr *ThumbnailError
+	if !errors.As(results[1].Err, &thumbErr) || thumbErr.Tag != "unsupported_extension" {
+		t.Errorf("GetThumbnailBatch results[1].Err is %#v, want an unsupported_extension *ThumbnailError", results[1].Err)
+	}
+}
+
+func TestGetThumbnailBatch_tooManyEntries(t *testing.T) {
+	entries := make([]ThumbnailBatchEntry, maxThumbnailBatchEntries+1)
+	if _, _, err := NewClient(nil).Files.GetThumbnailBatch(context.Background(), entries); err == nil {
+		t.Error("GetThumbnailBatch expected an error with too many entries")
+	}
+}
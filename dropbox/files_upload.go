@@ -0,0 +1,166 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WriteMode selects what to do if the file already exists when writing it.
+type WriteMode struct {
+	// One of "add", "overwrite" or "update".
+	Tag string `json:".tag"`
+
+	// The revision to overwrite when Tag is "update".
+	Update string `json:"update,omitempty"`
+}
+
+// WriteModeAdd returns a WriteMode that never overwrites the existing file.
+// The autorename strategy is to append a number to the file name.
+func WriteModeAdd() *WriteMode {
+	return &WriteMode{Tag: "add"}
+}
+
+// WriteModeOverwrite returns a WriteMode that always overwrites the existing
+// file.
+func WriteModeOverwrite() *WriteMode {
+	return &WriteMode{Tag: "overwrite"}
+}
+
+var writeModeUnion = newUnion("", map[string]interface{}{
+	"add":       nil,
+	"overwrite": nil,
+	"update":    "",
+})
+
+// MarshalJSON implements json.Marshaler.
+func (m WriteMode) MarshalJSON() ([]byte, error) {
+	return writeModeUnion.marshal(m)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *WriteMode) UnmarshalJSON(b []byte) error {
+	return writeModeUnion.unmarshal(b, m)
+}
+
+// WriteModeUpdate returns a WriteMode that overwrites the existing file only
+// if its current revision is rev. The autorename strategy is to append the
+// string "conflicted copy" to the file name.
+func WriteModeUpdate(rev string) *WriteMode {
+	return &WriteMode{Tag: "update", Update: rev}
+}
+
+// CommitInfo contains the options used to save uploaded content.
+type CommitInfo struct {
+	// Path in the user's Dropbox to save the file.
+	Path string `json:"path"`
+
+	// Selects what to do if the file already exists. Defaults to
+	// WriteModeAdd().
+	Mode *WriteMode `json:"mode,omitempty"`
+
+	// If there's a conflict, as determined by Mode, have the Dropbox server
+	// try to autorename the file to avoid conflict.
+	Autorename bool `json:"autorename"`
+
+	// The value to store as the ClientModified timestamp. If zero, Dropbox
+	// uses the time of the upload.
+	ClientModified time.Time `json:"-"`
+
+	// If true, the desktop clients are not notified about this modification.
+	Mute bool `json:"mute"`
+}
+
+// MarshalJSON implements json.Marshaler. Dropbox only accepts timestamps
+// in UTC and without fractional seconds.
+func (ci CommitInfo) MarshalJSON() ([]byte, error) {
+	type commitInfo CommitInfo
+	v := struct {
+		commitInfo
+		ClientModified string `json:"client_modified,omitempty"`
+	}{commitInfo: commitInfo(ci)}
+	if !ci.ClientModified.IsZero() {
+		v.ClientModified = ci.ClientModified.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	return json.Marshal(v)
+}
+
+// UploadError is returned by FilesService.Upload when the uploaded content
+// cannot be saved.
+type UploadError struct {
+	// One of "path", "properties_error", "payload_too_large",
+	// "content_hash_mismatch" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *UploadWriteFailed `json:"path,omitempty"`
+}
+
+// UploadWriteFailed describes why uploaded content could not be saved.
+type UploadWriteFailed struct {
+	// The reason why the file couldn't be saved.
+	Reason *WriteError `json:"reason"`
+
+	// The upload session ID, if any. It may be used to retry the commit.
+	UploadSessionID string `json:"upload_session_id"`
+}
+
+func (e *UploadError) Error() string {
+	if e.Tag == "path" && e.Path != nil && e.Path.Reason != nil {
+		return "upload failed: path/" + e.Path.Reason.Error()
+	}
+	return "upload failed: " + e.Tag
+}
+
+var uploadErrorUnion = newUnion("other", map[string]interface{}{
+	"path":                  UploadWriteFailed{},
+	"properties_error":      nil,
+	"payload_too_large":     nil,
+	"content_hash_mismatch": nil,
+	"other":                 nil,
+})
+
+// Unwrap returns the reason why the file could not be saved, if any.
+func (e *UploadError) Unwrap() error {
+	if e.Path == nil || e.Path.Reason == nil {
+		return nil
+	}
+	return e.Path.Reason
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *UploadError) UnmarshalJSON(b []byte) error {
+	return uploadErrorUnion.unmarshal(b, e)
+}
+
+// Upload creates a new file with the contents read from r. The file is saved
+// at path using the options in opts, if not nil. Errors saving the file are
+// returned as an *APIError holding an *UploadError.
+//
+// Upload does not support uploading files larger than 150 MB.
+func (s *FilesService) Upload(ctx context.Context, path string, r io.Reader, opts *CommitInfo) (*FileMetadata, *http.Response, error) {
+	var arg CommitInfo
+	if opts != nil {
+		arg = *opts
+	}
+	arg.Path = path
+
+	req, err := s.client.NewUploadRequest("POST", "2-beta/files/upload", &arg, r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var meta FileMetadata
+	resp, err := s.client.do(ctx, (*http.Request)(req), &meta, new(UploadError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &meta, resp, nil
+}
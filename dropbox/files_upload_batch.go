@@ -0,0 +1,234 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// maxFinishBatchEntries is the maximum number of entries accepted by the
+// upload_session/finish_batch endpoint.
+const maxFinishBatchEntries = 1000
+
+// UploadBatchEntry is a file uploaded by UploadBatch.
+type UploadBatchEntry struct {
+	// The contents of the file.
+	Reader io.Reader
+
+	// The options used to save the file, including its path.
+	Commit CommitInfo
+}
+
+// UploadBatchResult is the result of uploading an UploadBatchEntry.
+type UploadBatchResult struct {
+	// The metadata of the saved file, if it was successfully uploaded.
+	Metadata *FileMetadata
+
+	// The error uploading or saving the file, if any.
+	Err error
+}
+
+// UploadBatchOptions contains the options used by UploadBatch.
+type UploadBatchOptions struct {
+	// The amount of bytes sent in each request. Defaults to DefaultChunkSize.
+	ChunkSize int
+
+	// The maximum number of files uploaded at the same time. Defaults to 4.
+	Workers int
+
+	// The policy used to space the checks of the batch commit status. If
+	// nil, the PollPolicy of the client is used.
+	PollPolicy *PollPolicy
+}
+
+// UploadBatch uploads several files, using at most Workers upload sessions
+// at the same time, and saves all of them with a single batch commit. The
+// results are returned in the same order as the entries. A failure uploading
+// or saving a file is reported in its result and does not abort the rest of
+// the batch. The files are committed in batches of up to 1000; if a batch
+// commit fails as a whole, its error is returned together with the results,
+// where the files of that batch and of the following ones hold the error, and
+// the files of the previous batches hold their committed metadata.
+func (s *FilesService) UploadBatch(ctx context.Context, entries []UploadBatchEntry, opts *UploadBatchOptions) ([]UploadBatchResult, error) {
+	if opts == nil {
+		opts = &UploadBatchOptions{}
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = 4
+	}
+
+	results := make([]UploadBatchResult, len(entries))
+	cursors := make([]UploadSessionCursor, len(entries))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				cursors[i], results[i].Err = s.uploadSessionContent(ctx, entries[i].Reader, chunkSize)
+			}
+		}()
+	}
+	for i := range entries {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	var pending []int
+	for i := range entries {
+		if results[i].Err == nil {
+			pending = append(pending, i)
+		}
+	}
+	for len(pending) > 0 {
+		n := len(pending)
+		if n > maxFinishBatchEntries {
+			n = maxFinishBatchEntries
+		}
+		batch := make([]uploadSessionFinishArg, n)
+		for j, i := range pending[:n] {
+			batch[j] = uploadSessionFinishArg{cursors[i], entries[i].Commit}
+		}
+		finished, err := s.uploadSessionFinishBatch(ctx, batch, opts.PollPolicy)
+		if err == nil && len(finished) != n {
+			err = errors.New("dropbox: unexpected number of upload batch results")
+		}
+		if err != nil {
+			// The previous batches are already committed, so only the
+			// entries not committed yet are failed.
+			for _, i := range pending {
+				results[i].Err = err
+			}
+			return results, err
+		}
+		for j, i := range pending[:n] {
+			switch {
+			case finished[j].Success != nil:
+				results[i].Metadata = finished[j].Success
+			case finished[j].Failure != nil:
+				results[i].Err = finished[j].Failure
+			default:
+				results[i].Err = &UploadSessionFinishError{Tag: finished[j].Tag}
+			}
+		}
+		pending = pending[n:]
+	}
+
+	return results, nil
+}
+
+// uploadSessionContent uploads the contents read from r to a new upload
+// session, and closes it.
+func (s *FilesService) uploadSessionContent(ctx context.Context, r io.Reader, chunkSize int) (UploadSessionCursor, error) {
+	var cursor UploadSessionCursor
+	for {
+		chunk, eof, err := readChunk(r, chunkSize)
+		if err != nil {
+			return cursor, err
+		}
+		if cursor.SessionID == "" {
+			id, _, err := s.UploadSessionStart(ctx, bytes.NewReader(chunk), &UploadSessionStartOptions{Close: eof})
+			if err != nil {
+				return cursor, err
+			}
+			cursor.SessionID = id
+		} else if _, err := s.UploadSessionAppend(ctx, cursor, bytes.NewReader(chunk), eof); err != nil {
+			return cursor, err
+		}
+		cursor.Offset += uint64(len(chunk))
+		if eof {
+			return cursor, nil
+		}
+	}
+}
+
+type uploadSessionFinishArg struct {
+	Cursor UploadSessionCursor `json:"cursor"`
+	Commit CommitInfo          `json:"commit"`
+}
+
+// uploadSessionFinishBatchStatus is either the launch result of a batch
+// commit or the result of checking its status.
+type uploadSessionFinishBatchStatus struct {
+	// One of "async_job_id", "in_progress", "complete" or "other".
+	Tag        string                          `json:".tag"`
+	AsyncJobID string                          `json:"async_job_id"`
+	Complete   *uploadSessionFinishBatchResult `json:"complete"`
+}
+
+var uploadSessionFinishBatchStatusUnion = newUnion("other", map[string]interface{}{
+	"async_job_id": "",
+	"in_progress":  nil,
+	"complete":     uploadSessionFinishBatchResult{},
+	"other":        nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *uploadSessionFinishBatchStatus) UnmarshalJSON(b []byte) error {
+	return uploadSessionFinishBatchStatusUnion.unmarshal(b, s)
+}
+
+func (s *uploadSessionFinishBatchStatus) Pending() bool {
+	return s.Tag == "async_job_id" || s.Tag == "in_progress"
+}
+
+type uploadSessionFinishBatchResult struct {
+	Entries []uploadSessionFinishBatchResultItem `json:"entries"`
+}
+
+type uploadSessionFinishBatchResultItem struct {
+	// One of "success", "failure" or "other".
+	Tag     string                    `json:".tag"`
+	Success *FileMetadata             `json:"success"`
+	Failure *UploadSessionFinishError `json:"failure"`
+}
+
+var uploadSessionFinishBatchResultItemUnion = newUnion("other", map[string]interface{}{
+	"success": FileMetadata{},
+	"failure": UploadSessionFinishError{},
+	"other":   nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *uploadSessionFinishBatchResultItem) UnmarshalJSON(b []byte) error {
+	return uploadSessionFinishBatchResultItemUnion.unmarshal(b, e)
+}
+
+// uploadSessionFinishBatch commits several closed upload sessions, waiting
+// for the batch job to complete. Status checks are spaced as configured by
+// policy, or by the client if nil.
+func (s *FilesService) uploadSessionFinishBatch(ctx context.Context, entries []uploadSessionFinishArg, policy *PollPolicy) ([]uploadSessionFinishBatchResultItem, error) {
+	arg := struct {
+		Entries []uploadSessionFinishArg `json:"entries"`
+	}{entries}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/files/upload_session/finish_batch", &arg)
+	if err != nil {
+		return nil, err
+	}
+	var status uploadSessionFinishBatchStatus
+	if _, err := s.client.DoRPC(ctx, req, &status); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.client.WaitAsyncJob(ctx, "2-beta/files/upload_session/finish_batch/check", status.AsyncJobID, &status, policy); err != nil {
+		return nil, err
+	}
+	if status.Tag != "complete" || status.Complete == nil {
+		return nil, errors.New("dropbox: upload batch finished with status " + status.Tag)
+	}
+
+	return status.Complete.Entries, nil
+}
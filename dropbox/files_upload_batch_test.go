@@ -0,0 +1,299 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUploadBatch(t *testing.T) {
+	setup()
+	defer teardown()
+	srv := newUploadSessionServer(t)
+
+	var finishArgs []uploadSessionFinishArg
+	mux.HandleFunc("/2-beta/files/upload_session/finish_batch", func(w http.ResponseWriter, r *http.Request) {
+		var arg struct {
+			Entries []uploadSessionFinishArg `json:"entries"`
+		}
+		json.NewDecoder(r.Body).Decode(&arg)
+		finishArgs = arg.Entries
+		fmt.Fprint(w, `{".tag":"async_job_id","async_job_id":"job-1"}`)
+	})
+	checks := 0
+	mux.HandleFunc("/2-beta/files/upload_session/finish_batch/check", func(w http.ResponseWriter, r *http.Request) {
+		var arg struct {
+			AsyncJobID string `json:"async_job_id"`
+		}
+		json.NewDecoder(r.Body).Decode(&arg)
+		if got, want := arg.AsyncJobID, "job-1"; got != want {
+			t.Errorf("finish_batch/check async_job_id is %v, want %v", got, want)
+		}
+		checks++
+		if checks == 1 {
+			fmt.Fprint(w, `{".tag":"in_progress"}`)
+			return
+		}
+		var entries []string
+		for _, e := range finishArgs {
+			data := srv.sessions[e.Cursor.SessionID]
+			if e.Commit.Path == "/conflict.txt" {
+				entries = append(entries, `{".tag":"failure","failure":{".tag":"path","path":{".tag":"conflict","conflict":{".tag":"file"}}}}`)
+				continue
+			}
+			entries = append(entries, fmt.Sprintf(`{".tag":"success","path_display":%q,"size":%d}`, e.Commit.Path, data.Len()))
+		}
+		fmt.Fprintf(w, `{".tag":"complete","entries":[%s]}`, strings.Join(entries, ","))
+	})
+
+	entries := []UploadBatchEntry{
+		{strings.NewReader("first file"), CommitInfo{Path: "/a.txt"}},
+		{strings.NewReader("conflicting file"), CommitInfo{Path: "/conflict.txt"}},
+		{strings.NewReader("third file, larger than a chunk"), CommitInfo{Path: "/c.txt"}},
+	}
+	results, err := client.Files.UploadBatch(context.Background(), entries, &UploadBatchOptions{
+		ChunkSize:  8,
+		PollPolicy: &PollPolicy{MinInterval: time.Millisecond, MaxInterval: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("UploadBatch returned unexpected error: %v", err)
+	}
+
+	if got, want := len(results), len(entries); got != want {
+		t.Fatalf("UploadBatch returned %v results, want %v", got, want)
+	}
+	if got, want := results[0].Metadata.Size, uint64(10); results[0].Err != nil || got != want {
+		t.Errorf("UploadBatch results[0] is %+v, want a file of %v bytes", results[0], want)
+	}
+	if finishErr, ok := results[1].Err.(*UploadSessionFinishError); !ok || finishErr.Tag != "path" {
+		t.Errorf("UploadBatch results[1].Err is %#v, want a path *UploadSessionFinishError", results[1].Err)
+	}
+	if got, want := results[2].Metadata.Size, uint64(31); results[2].Err != nil || got != want {
+		t.Errorf("UploadBatch results[2] is %+v, want a file of %v bytes", results[2], want)
+	}
+}
+
+func TestUploadBatch_partialFailure(t *testing.T) {
+	setup()
+	defer teardown()
+	newUploadSessionServer(t)
+
+	batches := 0
+	mux.HandleFunc("/2-beta/files/upload_session/finish_batch", func(w http.ResponseWriter, r *http.Request) {
+		var arg struct {
+			Entries []uploadSessionFinishArg `json:"entries"`
+		}
+		json.NewDecoder(r.Body).Decode(&arg)
+		batches++
+		if batches > 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(409)
+			fmt.Fprint(w, `{"error_summary":"other/..","error":{".tag":"other"}}`)
+			return
+		}
+		entries := make([]string, len(arg.Entries))
+		for i, e := range arg.Entries {
+			entries[i] = fmt.Sprintf(`{".tag":"success","path_display":%q}`, e.Commit.Path)
+		}
+		fmt.Fprintf(w, `{".tag":"complete","entries":[%s]}`, strings.Join(entries, ","))
+	})
+
+	entries := make([]UploadBatchEntry, maxFinishBatchEntries+1)
+	for i := range entries {
+		entries[i] = UploadBatchEntry{strings.NewReader("a"), CommitInfo{Path: fmt.Sprintf("/%d.txt", i)}}
+	}
+	results, err := client.Files.UploadBatch(context.Background(), entries, nil)
+	if err == nil {
+		t.Fatal("UploadBatch expected error to be returned")
+	}
+	if got, want := len(results), len(entries); got != want {
+		t.Fatalf("UploadBatch returned %v results, want %v", got, want)
+	}
+	if got := results[0]; got.Err != nil || got.Metadata == nil || got.Metadata.PathDisplay != "/0.txt" {
+		t.Errorf("UploadBatch results[0] is %+v, want the committed /0.txt", got)
+	}
+	if got := results[maxFinishBatchEntries]; got.Err != err || got.Metadata != nil {
+		t.Errorf("UploadBatch results[%d] is %+v, want the batch error", maxFinishBatchEntries, got)
+	}
+}
+
+func TestUploadConcurrent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var (
+		mu     sync.Mutex
+		chunks = make(map[uint64][]byte)
+		closed bool
+		saved  []byte
+	)
+	mux.HandleFunc("/2-beta/files/upload_session/start", func(w http.ResponseWriter, r *http.Request) {
+		var arg UploadSessionStartOptions
+		json.Unmarshal([]byte(r.Header.Get("Dropbox-API-Arg")), &arg)
+		if got, want := arg.SessionType, "concurrent"; got != want {
+			t.Errorf("upload_session/start session_type is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"session_id":"concurrent-session"}`)
+	})
+	mux.HandleFunc("/2-beta/files/upload_session/append_v2", func(w http.ResponseWriter, r *http.Request) {
+		var arg struct {
+			Cursor UploadSessionCursor `json:"cursor"`
+			Close  bool                `json:"close"`
+		}
+		json.Unmarshal([]byte(r.Header.Get("Dropbox-API-Arg")), &arg)
+		data, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			t.Errorf("upload_session/append_v2 at offset %v called on a closed session", arg.Cursor.Offset)
+		}
+		chunks[arg.Cursor.Offset] = data
+		closed = closed || arg.Close
+		fmt.Fprint(w, "null")
+	})
+	mux.HandleFunc("/2-beta/files/upload_session/finish", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !closed {
+			t.Error("upload_session/finish called on a not closed session")
+		}
+		var offsets []int
+		for offset := range chunks {
+			offsets = append(offsets, int(offset))
+		}
+		sort.Ints(offsets)
+		for _, offset := range offsets {
+			saved = append(saved, chunks[uint64(offset)]...)
+		}
+		json.NewEncoder(w).Encode(FileMetadata{Size: uint64(len(saved))})
+	})
+
+	content := bytes.Repeat([]byte("0123456789abcdef"), (2*ConcurrentChunkSize+100)/16)
+	meta, _, err := client.Files.UploadConcurrent(context.Background(), "/big.bin", bytes.NewReader(content), nil, &UploadConcurrentOptions{
+		ChunkSize: ConcurrentChunkSize,
+		Workers:   2,
+	})
+	if err != nil {
+		t.Fatalf("UploadConcurrent returned unexpected error: %v", err)
+	}
+
+	if got, want := meta.Size, uint64(len(content)); got != want {
+		t.Errorf("UploadConcurrent FileMetadata.Size is %v, want %v", got, want)
+	}
+	if !bytes.Equal(saved, content) {
+		t.Error("UploadConcurrent uploaded content does not match")
+	}
+	if got, want := len(chunks), 3; got != want {
+		t.Errorf("UploadConcurrent appended %v chunks, want %v", got, want)
+	}
+}
+
+func TestUploadConcurrent_appendError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/upload_session/start", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"session_id":"concurrent-session"}`)
+	})
+	mux.HandleFunc("/2-beta/files/upload_session/append_v2", func(w http.ResponseWriter, r *http.Request) {
+		var arg struct {
+			Cursor UploadSessionCursor `json:"cursor"`
+			Close  bool                `json:"close"`
+		}
+		json.Unmarshal([]byte(r.Header.Get("Dropbox-API-Arg")), &arg)
+		ioutil.ReadAll(r.Body)
+		if arg.Close {
+			t.Error("upload_session/append_v2 closed the session after a failed append")
+		}
+		if arg.Cursor.Offset == 0 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(409)
+			fmt.Fprint(w, `{"error_summary":"incorrect_offset/..","error":{".tag":"incorrect_offset","correct_offset":0}}`)
+			return
+		}
+		// The other appends hang until the failure cancels them.
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+			t.Error("upload_session/append_v2 not cancelled after a failed append")
+		}
+	})
+	mux.HandleFunc("/2-beta/files/upload_session/finish", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("upload_session/finish called after a failed append")
+	})
+
+	content := bytes.Repeat([]byte("0123456789abcdef"), (2*ConcurrentChunkSize+100)/16)
+	_, _, err := client.Files.UploadConcurrent(context.Background(), "/big.bin", bytes.NewReader(content), nil, &UploadConcurrentOptions{
+		ChunkSize: ConcurrentChunkSize,
+		Workers:   2,
+	})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Errorf("UploadConcurrent returned error %#v, want an *APIError", err)
+	}
+}
+
+// zeroReader reads an endless stream of zeros, counting the bytes read.
+type zeroReader struct {
+	n int64
+}
+
+func (r *zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	atomic.AddInt64(&r.n, int64(len(p)))
+	return len(p), nil
+}
+
+func TestUploadConcurrent_stopsReading(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/upload_session/start", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"session_id":"concurrent-session"}`)
+	})
+	mux.HandleFunc("/2-beta/files/upload_session/append_v2", func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"not_found/..","error":{".tag":"not_found"}}`)
+	})
+
+	// The source is limited so that a regression fails instead of hanging.
+	const maxChunks = 64
+	src := new(zeroReader)
+	_, _, err := client.Files.UploadConcurrent(context.Background(), "/big.bin", io.LimitReader(src, maxChunks*ConcurrentChunkSize), nil, &UploadConcurrentOptions{
+		ChunkSize: ConcurrentChunkSize,
+		Workers:   1,
+	})
+	if err == nil {
+		t.Fatal("UploadConcurrent expected error to be returned")
+	}
+	if read := atomic.LoadInt64(&src.n) / ConcurrentChunkSize; read > 4 {
+		t.Errorf("UploadConcurrent read %v chunks after a failed append, want at most 4", read)
+	}
+}
+
+func TestUploadConcurrent_invalidChunkSize(t *testing.T) {
+	c := NewClient(nil)
+	_, _, err := c.Files.UploadConcurrent(context.Background(), "/a.txt", strings.NewReader("a"), nil, &UploadConcurrentOptions{ChunkSize: 1000})
+	if err == nil {
+		t.Error("UploadConcurrent expected error to be returned")
+	}
+}
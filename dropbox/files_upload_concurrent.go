@@ -0,0 +1,152 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ConcurrentChunkSize is the granularity of the chunks appended to
+// concurrent upload sessions. Every chunk except the last one must be a
+// multiple of it.
+const ConcurrentChunkSize = 4 << 20
+
+// UploadConcurrentOptions contains the options used by UploadConcurrent.
+type UploadConcurrentOptions struct {
+	// The amount of bytes sent in each request. It must be a multiple of
+	// ConcurrentChunkSize. Defaults to DefaultChunkSize.
+	ChunkSize int
+
+	// The maximum number of chunks uploaded at the same time. Defaults to 4.
+	Workers int
+}
+
+// UploadConcurrent uploads the contents read from r using a concurrent
+// upload session, appending several chunks in parallel, and saves them at
+// path using the options in commit, if not nil. At most Workers+2 chunks are
+// kept in memory at the same time.
+func (s *FilesService) UploadConcurrent(ctx context.Context, path string, r io.Reader, commit *CommitInfo, opts *UploadConcurrentOptions) (*FileMetadata, *http.Response, error) {
+	if opts == nil {
+		opts = &UploadConcurrentOptions{}
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if chunkSize%ConcurrentChunkSize != 0 {
+		return nil, nil, errors.New("dropbox: chunk size must be a multiple of ConcurrentChunkSize")
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = 4
+	}
+
+	chunk, eof, err := readChunk(r, chunkSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	if eof {
+		return s.Upload(ctx, path, bytes.NewReader(chunk), commit)
+	}
+
+	id, resp, err := s.UploadSessionStart(ctx, nil, &UploadSessionStartOptions{SessionType: "concurrent"})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	// The chunks are appended concurrently, except the last one, which
+	// closes the session and so is appended once all the others are done.
+	appendCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type job struct {
+		cursor UploadSessionCursor
+		chunk  []byte
+	}
+	jobs := make(chan job)
+	done := make(chan struct{})
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		errResp  *http.Response
+	)
+	fail := func(err error, resp *http.Response) {
+		once.Do(func() {
+			firstErr, errResp = err, resp
+			close(done)
+			cancel()
+		})
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				resp, err := s.UploadSessionAppend(appendCtx, j.cursor, bytes.NewReader(j.chunk), false)
+				if err != nil {
+					fail(err, resp)
+				}
+			}
+		}()
+	}
+
+	var offset uint64
+read:
+	for {
+		next, eof, err := readChunk(r, chunkSize)
+		if err != nil {
+			fail(err, nil)
+			break
+		}
+		// An empty trailing chunk is never sent, the previous one is the last.
+		if eof && len(next) == 0 {
+			break
+		}
+		select {
+		case jobs <- job{UploadSessionCursor{id, offset}, chunk}:
+		case <-done:
+			// An append failed, so the rest of r is not read.
+			break read
+		}
+		offset += uint64(len(chunk))
+		chunk = next
+		if eof {
+			break
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, errResp, firstErr
+	}
+
+	resp, err = s.UploadSessionAppend(ctx, UploadSessionCursor{id, offset}, bytes.NewReader(chunk), true)
+	if err != nil {
+		return nil, resp, err
+	}
+	offset += uint64(len(chunk))
+
+	var ci CommitInfo
+	if commit != nil {
+		ci = *commit
+	}
+	ci.Path = path
+	return s.UploadSessionFinish(ctx, UploadSessionCursor{id, offset}, nil, &ci)
+}
+
+// readChunk reads up to size bytes from r, reporting if r has no more data.
+func readChunk(r io.Reader, size int) ([]byte, bool, error) {
+	buf := make([]byte, size)
+	n, err := io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return buf[:n], true, nil
+	}
+	return buf[:n], false, err
+}
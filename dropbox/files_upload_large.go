@@ -0,0 +1,157 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// DefaultChunkSize is the size of the chunks sent by UploadLarge when no
+// chunk size is given.
+const DefaultChunkSize = 8 << 20
+
+// UploadLargeOptions contains the options used by UploadLarge.
+type UploadLargeOptions struct {
+	// The amount of bytes sent in each request. Defaults to DefaultChunkSize.
+	ChunkSize int
+
+	// The state of a previous upload session to resume. If nil, a new
+	// session is started.
+	State *UploadSessionCursor
+
+	// If not nil, it is called with the state of the upload session every
+	// time a chunk is sent. Storing it allows resuming the upload later.
+	OnProgress func(state UploadSessionCursor)
+}
+
+// UploadLarge uploads the contents read from r in chunks using an upload
+// session, and saves them at path using the options in commit, if not nil.
+//
+// The reader must yield the whole file content. When resuming a session, or
+// if the server reports that the session offset is not the expected one,
+// UploadLarge realigns the reader using Seek if r implements io.Seeker, or
+// discarding data otherwise.
+func (s *FilesService) UploadLarge(ctx context.Context, path string, r io.Reader, commit *CommitInfo, opts *UploadLargeOptions) (*FileMetadata, *http.Response, error) {
+	if opts == nil {
+		opts = &UploadLargeOptions{}
+	}
+	var ci CommitInfo
+	if commit != nil {
+		ci = *commit
+	}
+	ci.Path = path
+	var state UploadSessionCursor
+	if opts.State != nil {
+		state = *opts.State
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	cr := &chunkReader{r: r, buf: make([]byte, chunkSize)}
+	for {
+		chunk, eof, err := cr.chunk(state.Offset)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch {
+		case state.SessionID == "":
+			id, resp, err := s.UploadSessionStart(ctx, bytes.NewReader(chunk), nil)
+			if err != nil {
+				return nil, resp, err
+			}
+			state = UploadSessionCursor{SessionID: id, Offset: uint64(len(chunk))}
+		case eof:
+			meta, resp, err := s.UploadSessionFinish(ctx, state, bytes.NewReader(chunk), &ci)
+			if offset, ok := correctOffset(err); ok {
+				state.Offset = offset
+				continue
+			}
+			return meta, resp, err
+		default:
+			resp, err := s.UploadSessionAppend(ctx, state, bytes.NewReader(chunk), false)
+			if offset, ok := correctOffset(err); ok {
+				state.Offset = offset
+				continue
+			}
+			if err != nil {
+				return nil, resp, err
+			}
+			state.Offset += uint64(len(chunk))
+		}
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(state)
+		}
+	}
+}
+
+// correctOffset returns the offset expected by the server if err is an
+// incorrect offset error.
+func correctOffset(err error) (uint64, bool) {
+	var lookupErr *UploadSessionLookupError
+	if !errors.As(err, &lookupErr) || lookupErr.IncorrectOffset == nil {
+		return 0, false
+	}
+	return lookupErr.IncorrectOffset.CorrectOffset, true
+}
+
+// chunkReader reads chunks from any offset of r. The last chunk read is kept
+// so it can be read again from any offset inside it.
+type chunkReader struct {
+	r      io.Reader
+	pos    uint64 // offset of the next byte read from r
+	buf    []byte
+	bufOff uint64 // offset of buf[0]
+	bufLen int
+}
+
+// chunk returns the chunk starting at offset and if it is the last one.
+func (cr *chunkReader) chunk(offset uint64) ([]byte, bool, error) {
+	kept := 0
+	if cr.bufOff <= offset && offset < cr.bufOff+uint64(cr.bufLen) {
+		kept = copy(cr.buf, cr.buf[offset-cr.bufOff:cr.bufLen])
+	} else if err := cr.seek(offset); err != nil {
+		return nil, false, err
+	}
+
+	n, err := io.ReadFull(cr.r, cr.buf[kept:])
+	cr.pos += uint64(n)
+	cr.bufOff, cr.bufLen = offset, kept+n
+	eof := err == io.EOF || err == io.ErrUnexpectedEOF
+	if err != nil && !eof {
+		return nil, false, err
+	}
+	return cr.buf[:cr.bufLen], eof, nil
+}
+
+func (cr *chunkReader) seek(offset uint64) error {
+	if offset == cr.pos {
+		return nil
+	}
+	if seeker, ok := cr.r.(io.Seeker); ok {
+		if _, err := seeker.Seek(int64(offset), io.SeekStart); err != nil {
+			return err
+		}
+		cr.pos = offset
+		return nil
+	}
+	if offset < cr.pos {
+		return errors.New("dropbox: cannot rewind a reader that is not an io.Seeker")
+	}
+	n, err := io.CopyN(ioutil.Discard, cr.r, int64(offset-cr.pos))
+	cr.pos += uint64(n)
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+)
+
+// uploadSessionServer is a fake upload session backend. Sessions are stored
+// in memory, and offsets are checked as the Dropbox API does.
+type uploadSessionServer struct {
+	sync.Mutex
+	t        *testing.T
+	sessions map[string]*bytes.Buffer
+	files    map[string]string
+}
+
+func newUploadSessionServer(t *testing.T) *uploadSessionServer {
+	s := &uploadSessionServer{
+		t:        t,
+		sessions: make(map[string]*bytes.Buffer),
+		files:    make(map[string]string),
+	}
+	mux.HandleFunc("/2-beta/files/upload_session/start", s.start)
+	mux.HandleFunc("/2-beta/files/upload_session/append_v2", s.append)
+	mux.HandleFunc("/2-beta/files/upload_session/finish", s.finish)
+	return s
+}
+
+func (s *uploadSessionServer) start(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+	id := fmt.Sprintf("session-%d", len(s.sessions))
+	s.sessions[id] = new(bytes.Buffer)
+	s.sessions[id].ReadFrom(r.Body)
+	fmt.Fprintf(w, `{"session_id":%q}`, id)
+}
+
+func (s *uploadSessionServer) append(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+	var arg struct {
+		Cursor UploadSessionCursor `json:"cursor"`
+	}
+	json.Unmarshal([]byte(r.Header.Get("Dropbox-API-Arg")), &arg)
+	if !s.checkOffset(w, arg.Cursor, "") {
+		return
+	}
+	s.sessions[arg.Cursor.SessionID].ReadFrom(r.Body)
+	fmt.Fprint(w, "null")
+}
+
+func (s *uploadSessionServer) finish(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+	var arg struct {
+		Cursor UploadSessionCursor `json:"cursor"`
+		Commit CommitInfo          `json:"commit"`
+	}
+	json.Unmarshal([]byte(r.Header.Get("Dropbox-API-Arg")), &arg)
+	if !s.checkOffset(w, arg.Cursor, "lookup_failed") {
+		return
+	}
+	data := s.sessions[arg.Cursor.SessionID]
+	data.ReadFrom(r.Body)
+	s.files[arg.Commit.Path] = data.String()
+	json.NewEncoder(w).Encode(FileMetadata{PathDisplay: arg.Commit.Path, Size: uint64(data.Len())})
+}
+
+func (s *uploadSessionServer) checkOffset(w http.ResponseWriter, cursor UploadSessionCursor, wrapper string) bool {
+	data, ok := s.sessions[cursor.SessionID]
+	if !ok {
+		s.t.Errorf("Unknown upload session %q", cursor.SessionID)
+		return false
+	}
+	if uint64(data.Len()) == cursor.Offset {
+		return true
+	}
+	lookupErr := fmt.Sprintf(`{".tag":"incorrect_offset","correct_offset":%d}`, data.Len())
+	if wrapper != "" {
+		lookupErr = fmt.Sprintf(`{".tag":%q,%q:%s}`, wrapper, wrapper, lookupErr)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+	fmt.Fprintf(w, `{"error_summary":"incorrect_offset/..","error":%s}`, lookupErr)
+	return false
+}
+
+func TestUploadLarge(t *testing.T) {
+	setup()
+	defer teardown()
+	srv := newUploadSessionServer(t)
+
+	content := strings.Repeat("0123456789", 10)
+	var states []UploadSessionCursor
+	meta, _, err := client.Files.UploadLarge(context.Background(), "/big.txt", iotest.HalfReader(strings.NewReader(content)), nil, &UploadLargeOptions{
+		ChunkSize:  30,
+		OnProgress: func(state UploadSessionCursor) { states = append(states, state) },
+	})
+	if err != nil {
+		t.Fatalf("UploadLarge returned unexpected error: %v", err)
+	}
+
+	if got, want := meta.Size, uint64(len(content)); got != want {
+		t.Errorf("UploadLarge FileMetadata.Size is %v, want %v", got, want)
+	}
+	if got, want := srv.files["/big.txt"], content; got != want {
+		t.Errorf("UploadLarge uploaded %q, want %q", got, want)
+	}
+	if got, want := len(states), 3; got != want {
+		t.Errorf("UploadLarge reported %v states, want %v", got, want)
+	}
+}
+
+func TestUploadLarge_resume(t *testing.T) {
+	setup()
+	defer teardown()
+	srv := newUploadSessionServer(t)
+
+	content := strings.Repeat("0123456789", 10)
+	srv.sessions["resumed"] = bytes.NewBufferString(content[:45])
+
+	// The stored state is behind the server, so UploadLarge must realign
+	// inside the first chunk.
+	state := &UploadSessionCursor{SessionID: "resumed", Offset: 30}
+	_, _, err := client.Files.UploadLarge(context.Background(), "/big.txt", ioutil.NopCloser(strings.NewReader(content)), nil, &UploadLargeOptions{
+		ChunkSize: 30,
+		State:     state,
+	})
+	if err != nil {
+		t.Fatalf("UploadLarge returned unexpected error: %v", err)
+	}
+
+	if got, want := srv.files["/big.txt"], content; got != want {
+		t.Errorf("UploadLarge uploaded %q, want %q", got, want)
+	}
+}
+
+func TestUploadLarge_resumeSeeker(t *testing.T) {
+	setup()
+	defer teardown()
+	srv := newUploadSessionServer(t)
+
+	content := strings.Repeat("0123456789", 10)
+	srv.sessions["resumed"] = bytes.NewBufferString(content[:95])
+
+	// The stored state is behind the server, past the first chunk, so the
+	// reader is seeked to the offset reported by the server.
+	state := &UploadSessionCursor{SessionID: "resumed", Offset: 10}
+	_, _, err := client.Files.UploadLarge(context.Background(), "/big.txt", strings.NewReader(content), nil, &UploadLargeOptions{
+		ChunkSize: 30,
+		State:     state,
+	})
+	if err != nil {
+		t.Fatalf("UploadLarge returned unexpected error: %v", err)
+	}
+
+	if got, want := srv.files["/big.txt"], content; got != want {
+		t.Errorf("UploadLarge uploaded %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// UploadSessionCursor points to a position in an upload session. It is JSON
+// serializable, so it can be stored to resume an upload later.
+type UploadSessionCursor struct {
+	// The upload session ID.
+	SessionID string `json:"session_id"`
+
+	// The amount of data that has been uploaded so far.
+	Offset uint64 `json:"offset"`
+}
+
+// UploadSessionStartOptions contains the options used to start an upload
+// session.
+type UploadSessionStartOptions struct {
+	// If true, the current session will be closed, and no more data can be
+	// appended to it.
+	Close bool `json:"close"`
+
+	// Either "sequential" or "concurrent". Data of concurrent sessions can be
+	// appended in any order and in parallel. Defaults to "sequential".
+	SessionType string `json:"session_type,omitempty"`
+}
+
+// UploadSessionLookupError describes why an upload session could not be
+// found or used.
+type UploadSessionLookupError struct {
+	// One of "not_found", "incorrect_offset", "closed", "not_closed",
+	// "too_large", "concurrent_session_invalid_offset",
+	// "concurrent_session_invalid_data_size", "payload_too_large" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "incorrect_offset".
+	IncorrectOffset *UploadSessionOffsetError `json:"incorrect_offset,omitempty"`
+}
+
+// UploadSessionOffsetError is returned when the offset of an upload session
+// cursor does not match the amount of data received by the server.
+type UploadSessionOffsetError struct {
+	// The offset up to which data has been collected.
+	CorrectOffset uint64 `json:"correct_offset"`
+}
+
+func (e *UploadSessionLookupError) Error() string {
+	return "upload session lookup failed: " + e.Tag
+}
+
+var uploadSessionLookupErrorUnion = newUnion("other", map[string]interface{}{
+	"not_found":                            nil,
+	"incorrect_offset":                     UploadSessionOffsetError{},
+	"closed":                               nil,
+	"not_closed":                           nil,
+	"too_large":                            nil,
+	"concurrent_session_invalid_offset":    nil,
+	"concurrent_session_invalid_data_size": nil,
+	"payload_too_large":                    nil,
+	"other":                                nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *UploadSessionLookupError) UnmarshalJSON(b []byte) error {
+	return uploadSessionLookupErrorUnion.unmarshal(b, e)
+}
+
+// UploadSessionFinishError describes why an upload session could not be
+// committed.
+type UploadSessionFinishError struct {
+	// One of "lookup_failed", "path", "too_many_shared_folder_targets",
+	// "too_many_write_operations" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "lookup_failed".
+	LookupFailed *UploadSessionLookupError `json:"lookup_failed,omitempty"`
+
+	// Set when Tag is "path".
+	Path *WriteError `json:"path,omitempty"`
+}
+
+var uploadSessionFinishErrorUnion = newUnion("other", map[string]interface{}{
+	"lookup_failed":                       UploadSessionLookupError{},
+	"path":                                WriteError{},
+	"properties_error":                    nil,
+	"too_many_shared_folder_targets":      nil,
+	"too_many_write_operations":           nil,
+	"concurrent_session_data_not_allowed": nil,
+	"concurrent_session_not_closed":       nil,
+	"concurrent_session_missing_data":     nil,
+	"payload_too_large":                   nil,
+	"content_hash_mismatch":               nil,
+	"other":                               nil,
+})
+
+// Unwrap returns the lookup or write error, if any.
+func (e *UploadSessionFinishError) Unwrap() error {
+	switch {
+	case e.LookupFailed != nil:
+		return e.LookupFailed
+	case e.Path != nil:
+		return e.Path
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *UploadSessionFinishError) UnmarshalJSON(b []byte) error {
+	return uploadSessionFinishErrorUnion.unmarshal(b, e)
+}
+
+func (e *UploadSessionFinishError) Error() string {
+	switch {
+	case e.Tag == "lookup_failed" && e.LookupFailed != nil:
+		return "upload session finish failed: lookup_failed/" + e.LookupFailed.Tag
+	case e.Tag == "path" && e.Path != nil:
+		return "upload session finish failed: path/" + e.Path.Error()
+	}
+	return "upload session finish failed: " + e.Tag
+}
+
+// UploadSessionStart starts a new upload session with the contents read from
+// r, which may be empty. The returned session ID is used to append more data
+// with UploadSessionAppend and to save the file with UploadSessionFinish.
+func (s *FilesService) UploadSessionStart(ctx context.Context, r io.Reader, opts *UploadSessionStartOptions) (string, *http.Response, error) {
+	if opts == nil {
+		opts = &UploadSessionStartOptions{}
+	}
+	req, err := s.client.NewUploadRequest("POST", "2-beta/files/upload_session/start", opts, r)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var result struct {
+		SessionID string `json:"session_id"`
+	}
+	resp, err := s.client.DoUpload(ctx, req, &result)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return result.SessionID, resp, nil
+}
+
+// UploadSessionAppend appends the contents read from r to the upload session
+// at cursor. If close is true, no more data can be appended to the session.
+// If cursor.Offset does not match the data received by the server, an
+// *APIError holding an *UploadSessionLookupError with the correct offset is
+// returned.
+func (s *FilesService) UploadSessionAppend(ctx context.Context, cursor UploadSessionCursor, r io.Reader, close bool) (*http.Response, error) {
+	arg := struct {
+		Cursor UploadSessionCursor `json:"cursor"`
+		Close  bool                `json:"close"`
+	}{cursor, close}
+	req, err := s.client.NewUploadRequest("POST", "2-beta/files/upload_session/append_v2", &arg, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.do(ctx, (*http.Request)(req), nil, new(UploadSessionLookupError))
+}
+
+// UploadSessionFinish appends the contents read from r to the upload session
+// at cursor and saves all the session data to a file, using the options in
+// commit. Errors are returned as an *APIError holding an
+// *UploadSessionFinishError.
+func (s *FilesService) UploadSessionFinish(ctx context.Context, cursor UploadSessionCursor, r io.Reader, commit *CommitInfo) (*FileMetadata, *http.Response, error) {
+	arg := struct {
+		Cursor UploadSessionCursor `json:"cursor"`
+		Commit *CommitInfo         `json:"commit"`
+	}{cursor, commit}
+	req, err := s.client.NewUploadRequest("POST", "2-beta/files/upload_session/finish", &arg, r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var meta FileMetadata
+	resp, err := s.client.do(ctx, (*http.Request)(req), &meta, new(UploadSessionFinishError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &meta, resp, nil
+}
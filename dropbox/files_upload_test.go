@@ -0,0 +1,70 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommitInfo_MarshalJSON(t *testing.T) {
+	ci := CommitInfo{
+		Path:           "/a.txt",
+		Mode:           WriteModeUpdate("a1c10ce0dd78"),
+		ClientModified: time.Date(2015, 5, 12, 17, 50, 38, 500, time.FixedZone("", 2*60*60)),
+	}
+	blob, err := json.Marshal(ci)
+	if err != nil {
+		t.Fatalf("json.Marshal(CommitInfo) returned unexpected error: %v", err)
+	}
+	want := `{"path":"/a.txt","mode":{".tag":"update","update":"a1c10ce0dd78"},"autorename":false,"mute":false,"client_modified":"2015-05-12T15:50:38Z"}`
+	if got := string(blob); got != want {
+		t.Errorf("json.Marshal(CommitInfo) is %v, want %v", got, want)
+	}
+}
+
+func TestUpload_conflict(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/upload", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"path/conflict/file/..","error":{".tag":"path","reason":{".tag":"conflict","conflict":{".tag":"file"}},"upload_session_id":"s1"}}`)
+	})
+
+	_, _, err := client.Files.Upload(context.Background(), "/a.txt", strings.NewReader("a"), nil)
+	var uploadErr *UploadError
+	if !errors.As(err, &uploadErr) {
+		t.Fatalf("Upload expected an *UploadError; got %#v", err)
+	}
+	if uploadErr.Tag != "path" || uploadErr.Path == nil {
+		t.Fatalf("Upload returned %#v, want a path error", uploadErr)
+	}
+	if got, want := uploadErr.Path.UploadSessionID, "s1"; got != want {
+		t.Errorf("UploadWriteFailed.UploadSessionID is %v, want %v", got, want)
+	}
+	if got, want := uploadErr.Error(), "upload failed: path/conflict/file"; got != want {
+		t.Errorf("UploadError.Error() is %v, want %v", got, want)
+	}
+	var writeErr *WriteError
+	if !errors.As(err, &writeErr) || writeErr.Tag != "conflict" {
+		t.Errorf("Upload returned %#v, want a conflict *WriteError", err)
+	}
+}
+
+func TestWriteModeOverwrite_fresh(t *testing.T) {
+	mode := WriteModeOverwrite()
+	mode.Tag = "add"
+	if got, want := WriteModeOverwrite().Tag, "overwrite"; got != want {
+		t.Errorf("WriteModeOverwrite().Tag is %v after changing a previous mode, want %v", got, want)
+	}
+}
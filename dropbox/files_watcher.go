@@ -0,0 +1,300 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ChangeType is the kind of a change reported by a Watcher.
+type ChangeType int
+
+// Kinds of changes reported by a Watcher.
+const (
+	ChangeAdded ChangeType = iota
+	ChangeModified
+	ChangeDeleted
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeAdded:
+		return "added"
+	case ChangeModified:
+		return "modified"
+	case ChangeDeleted:
+		return "deleted"
+	}
+	return "unknown"
+}
+
+// ChangeEvent is a change in a folder watched by a Watcher.
+type ChangeEvent struct {
+	// The kind of change.
+	Type ChangeType
+
+	// The metadata of the changed entry. It is a *DeletedMetadata when Type
+	// is ChangeDeleted.
+	Metadata Metadata
+}
+
+// WatchOptions contains the options used to watch a folder.
+type WatchOptions struct {
+	// The options used to list the folder. IncludeDeleted is ignored.
+	ListFolderOptions
+
+	// The cursor to start watching from, usually one returned by
+	// Watcher.Cursor before. If empty, the folder is listed first, and only
+	// the changes made after that are reported.
+	Cursor string
+
+	// The time each long poll request waits for changes. See
+	// FilesService.ListFolderLongpoll.
+	LongpollTimeout time.Duration
+}
+
+// Watcher reports the changes made to a folder. The changes are detected
+// using long polling and fetched with list_folder/continue. If the cursor is
+// reset by the server, the folder is listed again and the differences with
+// the previous listing are reported.
+//
+// Transient errors, such as network errors, rate limiting or server errors,
+// are retried with backoff as configured by the RetryPolicy of the client.
+// Once the attempts are exhausted, or on any other error, the Watcher stops;
+// it can be restarted without missing changes using Cursor.
+//
+// Watchers classify changes using the entries they have seen. When watching
+// from a stored cursor, entries that were not seen before are reported as
+// added, even if they were modified.
+type Watcher struct {
+	s     *FilesService
+	path  string
+	opts  WatchOptions
+	known map[string]Metadata
+
+	events chan ChangeEvent
+
+	mu     sync.Mutex
+	cursor string
+	err    error
+}
+
+// Watch starts watching the folder at path, using the options in opts, if
+// not nil, until ctx is done or an error happens.
+func (s *FilesService) Watch(ctx context.Context, path string, opts *WatchOptions) *Watcher {
+	if path == "/" {
+		path = ""
+	}
+	w := &Watcher{
+		s:      s,
+		path:   path,
+		known:  make(map[string]Metadata),
+		events: make(chan ChangeEvent),
+	}
+	if opts != nil {
+		w.opts = *opts
+	}
+	w.opts.IncludeDeleted = false
+	w.cursor = w.opts.Cursor
+	go w.run(ctx)
+	return w
+}
+
+// Events returns the channel where the changes are reported. It is closed
+// when the Watcher stops.
+func (w *Watcher) Events() <-chan ChangeEvent {
+	return w.events
+}
+
+// Cursor returns the cursor of the last changes reported. It can be stored
+// and used to watch the folder later, see WatchOptions.Cursor.
+func (w *Watcher) Cursor() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.cursor
+}
+
+// Err returns the error that stopped the Watcher, if any. It must be called
+// after the events channel is closed.
+func (w *Watcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+func (w *Watcher) setCursor(cursor string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.cursor = cursor
+}
+
+func (w *Watcher) run(ctx context.Context) {
+	defer close(w.events)
+	err := w.watch(ctx)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.err = err
+}
+
+func (w *Watcher) watch(ctx context.Context) error {
+	for failures := 0; ; {
+		err := w.poll(ctx)
+		if err == nil {
+			failures = 0
+			continue
+		}
+		failures++
+		policy := w.s.client.RetryPolicy
+		if !transient(err) || policy == nil || failures >= policy.MaxAttempts {
+			return err
+		}
+		if err := sleep(ctx, policy.backoff(failures, nil)); err != nil {
+			return err
+		}
+	}
+}
+
+// poll waits for changes after the current cursor and reports them, listing
+// the folder first if there is no cursor yet.
+func (w *Watcher) poll(ctx context.Context) error {
+	if w.Cursor() == "" {
+		return w.relist(ctx, false)
+	}
+	result, _, err := w.s.ListFolderLongpoll(ctx, w.Cursor(), w.opts.LongpollTimeout)
+	if err == nil && result.Changes {
+		err = w.fetchChanges(ctx)
+	}
+	if isCursorReset(err) {
+		err = w.relist(ctx, true)
+	}
+	if err != nil {
+		return err
+	}
+	if result != nil && result.Backoff > 0 {
+		return sleep(ctx, result.Backoff)
+	}
+	return nil
+}
+
+// fetchChanges reports the changes made after the current cursor.
+func (w *Watcher) fetchChanges(ctx context.Context) error {
+	it := w.s.ListFolderContinue(ctx, w.Cursor())
+	for it.Next() {
+		if err := w.apply(ctx, it.Metadata()); err != nil {
+			return err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	w.setCursor(it.Cursor())
+	return nil
+}
+
+// apply updates the known entries with entry, reporting the change.
+func (w *Watcher) apply(ctx context.Context, entry Metadata) error {
+	key := entry.GetPathLower()
+	old, seen := w.known[key]
+	switch m := entry.(type) {
+	case *DeletedMetadata:
+		for k := range w.known {
+			if k == key || strings.HasPrefix(k, key+"/") {
+				delete(w.known, k)
+			}
+		}
+		return w.emit(ctx, ChangeEvent{ChangeDeleted, m})
+	case *FileMetadata:
+		w.known[key] = m
+		if !seen {
+			return w.emit(ctx, ChangeEvent{ChangeAdded, m})
+		}
+		if f, ok := old.(*FileMetadata); !ok || f.Rev != m.Rev {
+			return w.emit(ctx, ChangeEvent{ChangeModified, m})
+		}
+	case *FolderMetadata:
+		w.known[key] = m
+		if !seen {
+			return w.emit(ctx, ChangeEvent{ChangeAdded, m})
+		}
+		if _, ok := old.(*FolderMetadata); !ok {
+			return w.emit(ctx, ChangeEvent{ChangeModified, m})
+		}
+	}
+	return nil
+}
+
+// relist lists the folder from scratch. If report is true, the differences
+// with the known entries are reported.
+func (w *Watcher) relist(ctx context.Context, report bool) error {
+	listed := make(map[string]bool)
+	it := w.s.ListFolderIterator(ctx, w.path, &w.opts.ListFolderOptions)
+	for it.Next() {
+		m := it.Metadata()
+		listed[m.GetPathLower()] = true
+		if !report {
+			w.known[m.GetPathLower()] = m
+			continue
+		}
+		if err := w.apply(ctx, m); err != nil {
+			return err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	for key, m := range w.known {
+		if listed[key] {
+			continue
+		}
+		delete(w.known, key)
+		if !report {
+			continue
+		}
+		deleted := &DeletedMetadata{
+			Name:        m.GetName(),
+			PathLower:   m.GetPathLower(),
+			PathDisplay: m.GetPathDisplay(),
+		}
+		if err := w.emit(ctx, ChangeEvent{ChangeDeleted, deleted}); err != nil {
+			return err
+		}
+	}
+	w.setCursor(it.Cursor())
+	return nil
+}
+
+func (w *Watcher) emit(ctx context.Context, e ChangeEvent) error {
+	select {
+	case w.events <- e:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// transient reports if err may not happen again, such as network errors,
+// rate limiting or server errors.
+func transient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return true
+}
+
+// isCursorReset reports if err is caused by an invalidated cursor.
+func isCursorReset(err error) bool {
+	var listErr *ListFolderError
+	return errors.As(err, &listErr) && listErr.Tag == "reset"
+}
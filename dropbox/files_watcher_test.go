@@ -0,0 +1,186 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWatcher(t *testing.T) {
+	setup()
+	defer teardown()
+
+	lists := 0
+	mux.HandleFunc("/2-beta/files/list_folder", func(w http.ResponseWriter, r *http.Request) {
+		lists++
+		if lists == 1 {
+			fmt.Fprint(w, `{"entries":[
+				{".tag":"file","name":"a.txt","path_lower":"/w/a.txt","rev":"1"},
+				{".tag":"folder","name":"B","path_lower":"/w/b"},
+				{".tag":"file","name":"b.txt","path_lower":"/w/b/b.txt","rev":"1"}
+			],"cursor":"c1","has_more":false}`)
+			return
+		}
+		fmt.Fprint(w, `{"entries":[
+			{".tag":"file","name":"a.txt","path_lower":"/w/a.txt","rev":"2"},
+			{".tag":"file","name":"d.txt","path_lower":"/w/d.txt","rev":"1"}
+		],"cursor":"c3","has_more":false}`)
+	})
+	mux.HandleFunc("/2-beta/files/list_folder/continue", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"entries":[
+			{".tag":"file","name":"a.txt","path_lower":"/w/a.txt","rev":"2"},
+			{".tag":"file","name":"c.txt","path_lower":"/w/c.txt","rev":"1"},
+			{".tag":"deleted","name":"B","path_lower":"/w/b"}
+		],"cursor":"c2","has_more":false}`)
+	})
+	mux.HandleFunc("/2-beta/files/list_folder/longpoll", func(w http.ResponseWriter, r *http.Request) {
+		var arg struct {
+			Cursor string `json:"cursor"`
+		}
+		json.NewDecoder(r.Body).Decode(&arg)
+		switch arg.Cursor {
+		case "c1":
+			fmt.Fprint(w, `{"changes":true}`)
+		case "c2":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(409)
+			fmt.Fprint(w, `{"error_summary":"reset/..","error":{".tag":"reset"}}`)
+		default:
+			<-r.Context().Done()
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher := client.Files.Watch(ctx, "/w", &WatchOptions{ListFolderOptions: ListFolderOptions{Recursive: true}})
+
+	want := []string{
+		"modified /w/a.txt",
+		"added /w/c.txt",
+		"deleted /w/b",
+		"added /w/d.txt",
+		"deleted /w/c.txt",
+	}
+	var got []string
+	for e := range watcher.Events() {
+		got = append(got, fmt.Sprintf("%v %s", e.Type, e.Metadata.GetPathLower()))
+		if len(got) == len(want) {
+			break
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Watcher events are %v, want %v", got, want)
+	}
+
+	cancel()
+	for range watcher.Events() {
+	}
+	if got, want := watcher.Err(), context.Canceled; !errors.Is(got, want) {
+		t.Errorf("Watcher.Err is %v, want %v", got, want)
+	}
+	if got, want := watcher.Cursor(), "c3"; got != want {
+		t.Errorf("Watcher.Cursor is %v, want %v", got, want)
+	}
+}
+
+func TestGetLatestCursor(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/list_folder/get_latest_cursor", func(w http.ResponseWriter, r *http.Request) {
+		var arg struct {
+			Path      string `json:"path"`
+			Recursive bool   `json:"recursive"`
+		}
+		json.NewDecoder(r.Body).Decode(&arg)
+		if arg.Path != "/w" || !arg.Recursive {
+			t.Errorf("get_latest_cursor arguments are %+v", arg)
+		}
+		fmt.Fprint(w, `{"cursor":"latest"}`)
+	})
+
+	cursor, _, err := client.Files.GetLatestCursor(context.Background(), "/w", &ListFolderOptions{Recursive: true})
+	if err != nil {
+		t.Fatalf("GetLatestCursor returned unexpected error: %v", err)
+	}
+	if got, want := cursor, "latest"; got != want {
+		t.Errorf("GetLatestCursor is %v, want %v", got, want)
+	}
+}
+
+// dropConnection closes the connection of r without responding, as a network
+// failure would do.
+func dropConnection(t *testing.T, w http.ResponseWriter) {
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		t.Fatalf("Hijack returned unexpected error: %v", err)
+	}
+	conn.Close()
+}
+
+func TestWatcher_retry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/files/list_folder/continue", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"entries":[{".tag":"file","name":"a.txt","path_lower":"/w/a.txt","rev":"1"}],"cursor":"c2","has_more":false}`)
+	})
+	var polls int32
+	mux.HandleFunc("/2-beta/files/list_folder/longpoll", func(w http.ResponseWriter, r *http.Request) {
+		switch n := atomic.AddInt32(&polls, 1); {
+		case n <= 2:
+			dropConnection(t, w)
+		case n == 3:
+			fmt.Fprint(w, `{"changes":true}`)
+		default:
+			<-r.Context().Done()
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher := client.Files.Watch(ctx, "/w", &WatchOptions{Cursor: "c1"})
+	e, ok := <-watcher.Events()
+	if !ok {
+		t.Fatalf("Watcher stopped with error %v, want it to retry", watcher.Err())
+	}
+	if got, want := e.Metadata.GetPathLower(), "/w/a.txt"; got != want {
+		t.Errorf("Watcher event path is %v, want %v", got, want)
+	}
+	cancel()
+	for range watcher.Events() {
+	}
+}
+
+func TestWatcher_giveUp(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var polls int32
+	mux.HandleFunc("/2-beta/files/list_folder/longpoll", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&polls, 1)
+		dropConnection(t, w)
+	})
+
+	watcher := client.Files.Watch(context.Background(), "/w", &WatchOptions{Cursor: "c1"})
+	for range watcher.Events() {
+	}
+	if watcher.Err() == nil {
+		t.Error("Watcher expected error to be returned")
+	}
+	if got, want := int(atomic.LoadInt32(&polls)), client.RetryPolicy.MaxAttempts; got != want {
+		t.Errorf("Watcher polled %v times, want %v", got, want)
+	}
+	if got, want := watcher.Cursor(), "c1"; got != want {
+		t.Errorf("Watcher.Cursor is %v, want %v", got, want)
+	}
+}
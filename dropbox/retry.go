@@ -0,0 +1,181 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how a Client retries failed requests. Requests
+// failing because of rate limiting (429 Too Many Requests) are always
+// retried, while requests failing because of server errors (5xx) are only
+// retried if they are idempotent. Requests whose body cannot be rewound are
+// never retried.
+type RetryPolicy struct {
+	// The maximum number of attempts of a request, including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// The base delay before the first retry. It is doubled after each
+	// attempt, and a random jitter is applied to it.
+	MinBackoff time.Duration
+
+	// The maximum delay between two attempts, unless the server asks for a
+	// longer one using the Retry-After header.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy used by new clients.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  time.Second,
+	MaxBackoff:  30 * time.Second,
+}
+
+// backoff returns the delay before retrying an attempt which got resp, if
+// any.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if d, ok := retryAfter(resp); ok {
+		return d
+	}
+	d := p.MinBackoff << uint(attempt-1)
+	if d > p.MaxBackoff || d <= 0 {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter returns the delay requested by the server in the Retry-After
+// header, either in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// retryable reports if req, which got resp, can be sent again.
+func retryable(req *http.Request, resp *http.Response) bool {
+	switch c := resp.StatusCode; {
+	case c == http.StatusTooManyRequests:
+		return true
+	case 500 <= c && c <= 599:
+		return idempotent(req)
+	}
+	return false
+}
+
+// idempotentHeader marks requests which can be safely sent more than once.
+// A nil value is not sent on the wire, but net/http also recognizes it.
+const idempotentHeader = "X-Idempotency-Key"
+
+// markIdempotent marks req as idempotent, so it may be retried after server
+// errors.
+func markIdempotent(req *http.Request) {
+	req.Header[idempotentHeader] = nil
+}
+
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "TRACE", "PUT", "DELETE":
+		return true
+	}
+	_, ok := req.Header[idempotentHeader]
+	return ok
+}
+
+// rewindable makes the body of req rewindable if it is an io.Seeker. The
+// body is not closed after sending req, as it belongs to the caller.
+func rewindable(req *http.Request, body io.Reader) error {
+	seeker, ok := body.(io.ReadSeeker)
+	if !ok || req.GetBody != nil {
+		return nil
+	}
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	req.Body = ioutil.NopCloser(seeker)
+	req.GetBody = func() (io.ReadCloser, error) {
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(seeker), nil
+	}
+	return nil
+}
+
+// rewind returns a copy of req that can be sent again.
+func rewind(req *http.Request) (*http.Request, bool) {
+	next := new(http.Request)
+	*next = *req
+	if req.Body == nil || req.Body == http.NoBody {
+		return next, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	next.Body = body
+	return next, true
+}
+
+// send sends req, retrying it as configured by c.RetryPolicy.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	for attempt := 1; ; attempt++ {
+		resp, err := c.client.Do(req)
+		if err != nil || c.RetryPolicy == nil || attempt >= c.RetryPolicy.MaxAttempts || !retryable(req, resp) {
+			return resp, err
+		}
+		next, ok := rewind(req)
+		if !ok {
+			return resp, nil
+		}
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		if err := sleep(ctx, c.RetryPolicy.backoff(attempt, resp)); err != nil {
+			return nil, err
+		}
+		req = next
+	}
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
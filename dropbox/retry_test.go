@@ -0,0 +1,166 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDoRPC_retryRateLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := string(bytes.TrimSpace(body)), `{"A":"in"}`; got != want {
+			t.Errorf("Attempt %d body is %v, want %v", attempts, got, want)
+		}
+		if attempts < 3 {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"error_summary":"too_many_requests/..","error":{"reason":{".tag":"too_many_requests"},"retry_after":0}}`)
+			return
+		}
+		fmt.Fprint(w, `{"A":"out"}`)
+	})
+
+	type foo struct {
+		A string
+	}
+	req, _ := client.NewRPCRequest("POST", "/", &foo{"in"})
+	body := new(foo)
+	if _, err := client.DoRPC(context.Background(), req, body); err != nil {
+		t.Fatalf("DoRPC returned unexpected error: %v", err)
+	}
+	if got, want := attempts, 3; got != want {
+		t.Errorf("DoRPC made %v attempts, want %v", got, want)
+	}
+	if got, want := body.A, "out"; got != want {
+		t.Errorf("Response body is %v, want %v", got, want)
+	}
+}
+
+func TestDoRPC_retryExhausted(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error_summary":"too_many_write_operations/..","error":{"reason":{".tag":"too_many_write_operations"},"retry_after":1}}`)
+	})
+
+	req, _ := client.NewRPCRequest("POST", "/", nil)
+	_, err := client.DoRPC(context.Background(), req, nil)
+	if got, want := attempts, client.RetryPolicy.MaxAttempts; got != want {
+		t.Errorf("DoRPC made %v attempts, want %v", got, want)
+	}
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) || rateErr.Reason.Tag != "too_many_write_operations" || rateErr.RetryAfter != 1 {
+		t.Errorf("DoRPC returned %#v, want a too_many_write_operations *RateLimitError", err)
+	}
+}
+
+func TestDoRPC_retryServerError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req, _ := client.NewRPCRequest("POST", "/", nil)
+	client.DoRPC(context.Background(), req, nil)
+	if got, want := attempts, 1; got != want {
+		t.Errorf("DoRPC made %v attempts of a non idempotent request, want %v", got, want)
+	}
+
+	attempts = 0
+	req, _ = client.NewRPCRequest("POST", "/", nil)
+	markIdempotent((*http.Request)(req))
+	client.DoRPC(context.Background(), req, nil)
+	if got, want := attempts, client.RetryPolicy.MaxAttempts; got != want {
+		t.Errorf("DoRPC made %v attempts of an idempotent request, want %v", got, want)
+	}
+}
+
+func TestDoUpload_retryRewindsBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := string(body), "content"; got != want {
+			t.Errorf("Attempt %d body is %v, want %v", attempts, got, want)
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, "null")
+	})
+
+	// A reader which is an io.Seeker, but which net/http does not know how
+	// to rewind.
+	r := struct{ *bytes.Reader }{bytes.NewReader([]byte("__content"))}
+	r.Seek(2, 0)
+	req, _ := client.NewUploadRequest("POST", "upload", nil, r)
+	if _, err := client.DoUpload(context.Background(), req, nil); err != nil {
+		t.Fatalf("DoUpload returned unexpected error: %v", err)
+	}
+	if got, want := attempts, 2; got != want {
+		t.Errorf("DoUpload made %v attempts, want %v", got, want)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	resp := &http.Response{Header: http.Header{}}
+	for attempt, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		d := p.backoff(attempt+1, resp)
+		if d < max/2 || d > max {
+			t.Errorf("backoff(%d) is %v, want a value in [%v, %v]", attempt+1, d, max/2, max)
+		}
+	}
+
+	resp.Header.Set("Retry-After", "42")
+	if got, want := p.backoff(1, resp), 42*time.Second; got != want {
+		t.Errorf("backoff(Retry-After: 42) is %v, want %v", got, want)
+	}
+}
+
+func TestDoRPC_retryCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := client.NewRPCRequest("POST", "/", nil)
+	_, err := client.DoRPC(ctx, req, nil)
+	if got, want := err, context.DeadlineExceeded; got != want {
+		t.Errorf("DoRPC returned %v, want %v", got, want)
+	}
+}
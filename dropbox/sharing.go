@@ -0,0 +1,11 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+// SharingService handles communication with the sharing related methods of
+// the Dropbox API.
+type SharingService struct {
+	client *Client
+}
@@ -0,0 +1,567 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// SharedFileMetadata contains the sharing metadata of a file.
+type SharedFileMetadata struct {
+	// The access level of the current user on the file.
+	AccessType AccessLevel `json:"access_type"`
+
+	// The ID of the file.
+	ID string `json:"id"`
+
+	// The name of the file.
+	Name string `json:"name"`
+
+	// The display names of the owners of the file.
+	OwnerDisplayNames []string `json:"owner_display_names,omitempty"`
+
+	// The ID of the shared folder that contains the file, if any.
+	ParentSharedFolderID string `json:"parent_shared_folder_id,omitempty"`
+
+	// The cased path of the file in the user's Dropbox, if the user has
+	// access to it through a mounted folder.
+	PathDisplay string `json:"path_display,omitempty"`
+
+	// The lowercased path of the file in the user's Dropbox, if the user has
+	// access to it through a mounted folder.
+	PathLower string `json:"path_lower,omitempty"`
+
+	// The sharing policies of the file, inherited from its shared folder.
+	Policy FolderPolicy `json:"policy"`
+
+	// The URL to preview the file on the Dropbox website.
+	PreviewURL string `json:"preview_url"`
+
+	// The time the current user was invited to the file, if any.
+	TimeInvited *time.Time `json:"time_invited,omitempty"`
+}
+
+// SharingUserError describes why the current user cannot perform a sharing
+// operation.
+type SharingUserError struct {
+	// Either "email_unverified" or "other".
+	Tag string `json:".tag"`
+}
+
+var sharingUserErrorUnion = newUnion("other", map[string]interface{}{
+	"email_unverified": nil,
+	"other":            nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SharingUserError) UnmarshalJSON(b []byte) error {
+	return sharingUserErrorUnion.unmarshal(b, e)
+}
+
+func (e *SharingUserError) Error() string {
+	return e.Tag
+}
+
+// SharingFileAccessError describes why a shared file could not be accessed.
+type SharingFileAccessError struct {
+	// One of "no_permission", "invalid_file", "is_folder",
+	// "inside_public_folder", "inside_osx_package" or "other".
+	Tag string `json:".tag"`
+}
+
+var sharingFileAccessErrorUnion = newUnion("other", map[string]interface{}{
+	"no_permission":        nil,
+	"invalid_file":         nil,
+	"is_folder":            nil,
+	"inside_public_folder": nil,
+	"inside_osx_package":   nil,
+	"other":                nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SharingFileAccessError) UnmarshalJSON(b []byte) error {
+	return sharingFileAccessErrorUnion.unmarshal(b, e)
+}
+
+func (e *SharingFileAccessError) Error() string {
+	return e.Tag
+}
+
+// SharingFileError describes why a sharing operation on a file failed. It is
+// the error of UnshareFile, GetFileMetadata, ListFileMembers and
+// ListFileMembersContinue.
+type SharingFileError struct {
+	// One of "user_error", "access_error", "invalid_cursor" or "other".
+	// "invalid_cursor" is only reported by ListFileMembersContinue.
+	Tag string `json:".tag"`
+
+	// Set when Tag is "user_error".
+	UserError *SharingUserError `json:"user_error,omitempty"`
+
+	// Set when Tag is "access_error".
+	AccessError *SharingFileAccessError `json:"access_error,omitempty"`
+}
+
+var sharingFileErrorUnion = newUnion("other", map[string]interface{}{
+	"user_error":     SharingUserError{},
+	"access_error":   SharingFileAccessError{},
+	"invalid_cursor": nil,
+	"other":          nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SharingFileError) UnmarshalJSON(b []byte) error {
+	return sharingFileErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the user or access error, if any.
+func (e *SharingFileError) Unwrap() error {
+	switch {
+	case e.UserError != nil:
+		return e.UserError
+	case e.AccessError != nil:
+		return e.AccessError
+	}
+	return nil
+}
+
+func (e *SharingFileError) Error() string {
+	if err := e.Unwrap(); err != nil {
+		return "file sharing failed: " + e.Tag + "/" + err.Error()
+	}
+	return "file sharing failed: " + e.Tag
+}
+
+// FileMemberActionError describes why an action on a member of a shared
+// file failed.
+type FileMemberActionError struct {
+	// One of "invalid_member", "no_permission", "access_error",
+	// "no_explicit_access" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "access_error".
+	AccessError *SharingFileAccessError `json:"access_error,omitempty"`
+
+	// The access the member has through its parent folders. Set when Tag is
+	// "no_explicit_access".
+	NoExplicitAccess *MemberAccessLevelResult `json:"no_explicit_access,omitempty"`
+}
+
+var fileMemberActionErrorUnion = newUnion("other", map[string]interface{}{
+	"invalid_member":     nil,
+	"no_permission":      nil,
+	"access_error":       SharingFileAccessError{},
+	"no_explicit_access": MemberAccessLevelResult{},
+	"other":              nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *FileMemberActionError) UnmarshalJSON(b []byte) error {
+	return fileMemberActionErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the access error, if any.
+func (e *FileMemberActionError) Unwrap() error {
+	if e.AccessError == nil {
+		return nil
+	}
+	return e.AccessError
+}
+
+func (e *FileMemberActionError) Error() string {
+	if e.AccessError != nil {
+		return "file member action failed: access_error/" + e.AccessError.Error()
+	}
+	return "file member action failed: " + e.Tag
+}
+
+// AddFileMemberOptions contains the options used to add members to a shared
+// file.
+type AddFileMemberOptions struct {
+	// A message included in the invitation.
+	CustomMessage string `json:"custom_message,omitempty"`
+
+	// If true, the members are not notified by email.
+	Quiet bool `json:"quiet"`
+
+	// The access level granted to the members. Defaults to AccessViewer.
+	AccessLevel AccessLevel `json:"access_level,omitempty"`
+
+	// If true, CustomMessage is also added as a comment on the file.
+	AddMessageAsComment bool `json:"add_message_as_comment"`
+}
+
+// FileMemberActionResult is the result of adding a member to a shared file.
+type FileMemberActionResult struct {
+	// The member the action was taken on.
+	Member MemberSelector
+
+	// The access level granted to the member, if the action succeeded and
+	// it was reported.
+	AccessLevel AccessLevel
+
+	// The error of the action, or nil if it succeeded.
+	Err *FileMemberActionError
+}
+
+type fileMemberActionIndividualResult struct {
+	Tag         string                 `json:".tag"`
+	Success     AccessLevel            `json:"success"`
+	MemberError *FileMemberActionError `json:"member_error"`
+}
+
+var fileMemberActionIndividualResultUnion = newUnion("other", map[string]interface{}{
+	"success":      AccessLevel(""),
+	"member_error": FileMemberActionError{},
+	"other":        nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *fileMemberActionIndividualResult) UnmarshalJSON(b []byte) error {
+	return fileMemberActionIndividualResultUnion.unmarshal(b, r)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *FileMemberActionResult) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Member MemberSelector                   `json:"member"`
+		Result fileMemberActionIndividualResult `json:"result"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*r = FileMemberActionResult{
+		Member:      v.Member,
+		AccessLevel: v.Result.Success,
+		Err:         v.Result.MemberError,
+	}
+	if r.Err == nil && v.Result.Tag != "success" {
+		r.Err = &FileMemberActionError{Tag: v.Result.Tag}
+	}
+	return nil
+}
+
+// AddFileMemberError describes why members could not be added to a shared
+// file.
+type AddFileMemberError struct {
+	// One of "user_error", "access_error", "rate_limit", "invalid_comment"
+	// or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "user_error".
+	UserError *SharingUserError `json:"user_error,omitempty"`
+
+	// Set when Tag is "access_error".
+	AccessError *SharingFileAccessError `json:"access_error,omitempty"`
+}
+
+var addFileMemberErrorUnion = newUnion("other", map[string]interface{}{
+	"user_error":      SharingUserError{},
+	"access_error":    SharingFileAccessError{},
+	"rate_limit":      nil,
+	"invalid_comment": nil,
+	"other":           nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *AddFileMemberError) UnmarshalJSON(b []byte) error {
+	return addFileMemberErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the user or access error, if any.
+func (e *AddFileMemberError) Unwrap() error {
+	switch {
+	case e.UserError != nil:
+		return e.UserError
+	case e.AccessError != nil:
+		return e.AccessError
+	}
+	return nil
+}
+
+func (e *AddFileMemberError) Error() string {
+	if err := e.Unwrap(); err != nil {
+		return "add file member failed: " + e.Tag + "/" + err.Error()
+	}
+	return "add file member failed: " + e.Tag
+}
+
+// AddFileMember adds members to file, given by path or ID, using the
+// options in opts, if not nil. The result of adding each member is
+// returned in the order of members. Errors are returned as an *APIError
+// holding an *AddFileMemberError.
+func (s *SharingService) AddFileMember(ctx context.Context, file string, members []MemberSelector, opts *AddFileMemberOptions) ([]FileMemberActionResult, *http.Response, error) {
+	arg := struct {
+		File    string           `json:"file"`
+		Members []MemberSelector `json:"members"`
+		AddFileMemberOptions
+	}{File: file, Members: members}
+	if opts != nil {
+		arg.AddFileMemberOptions = *opts
+	}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/add_file_member", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var results []FileMemberActionResult
+	resp, err := s.client.do(ctx, (*http.Request)(req), &results, new(AddFileMemberError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return results, resp, nil
+}
+
+// RemoveFileMemberError describes why a member could not be removed from a
+// shared file.
+type RemoveFileMemberError struct {
+	// One of "user_error", "access_error", "no_explicit_access" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "user_error".
+	UserError *SharingUserError `json:"user_error,omitempty"`
+
+	// Set when Tag is "access_error".
+	AccessError *SharingFileAccessError `json:"access_error,omitempty"`
+
+	// The access the member has through its parent folders. Set when Tag is
+	// "no_explicit_access".
+	NoExplicitAccess *MemberAccessLevelResult `json:"no_explicit_access,omitempty"`
+}
+
+var removeFileMemberErrorUnion = newUnion("other", map[string]interface{}{
+	"user_error":         SharingUserError{},
+	"access_error":       SharingFileAccessError{},
+	"no_explicit_access": MemberAccessLevelResult{},
+	"other":              nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *RemoveFileMemberError) UnmarshalJSON(b []byte) error {
+	return removeFileMemberErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the user or access error, if any.
+func (e *RemoveFileMemberError) Unwrap() error {
+	switch {
+	case e.UserError != nil:
+		return e.UserError
+	case e.AccessError != nil:
+		return e.AccessError
+	}
+	return nil
+}
+
+func (e *RemoveFileMemberError) Error() string {
+	if err := e.Unwrap(); err != nil {
+		return "remove file member failed: " + e.Tag + "/" + err.Error()
+	}
+	return "remove file member failed: " + e.Tag
+}
+
+type fileMemberRemoveActionResult struct {
+	Tag         string                   `json:".tag"`
+	Success     *MemberAccessLevelResult `json:"success"`
+	MemberError *FileMemberActionError   `json:"member_error"`
+}
+
+var fileMemberRemoveActionResultUnion = newUnion("other", map[string]interface{}{
+	"success":      MemberAccessLevelResult{},
+	"member_error": FileMemberActionError{},
+	"other":        nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *fileMemberRemoveActionResult) UnmarshalJSON(b []byte) error {
+	return fileMemberRemoveActionResultUnion.unmarshal(b, r)
+}
+
+// RemoveFileMember2 removes member from file, given by path or ID, and
+// returns the access the member keeps through its parent folders, if any.
+// Errors are returned as an *APIError holding a *RemoveFileMemberError, or
+// as a *FileMemberActionError if the member could not be removed.
+//
+// Unlike the other methods backed by versioned endpoints, it keeps the
+// version of remove_file_member_2 in its name, as its callers asked for it.
+func (s *SharingService) RemoveFileMember2(ctx context.Context, file string, member MemberSelector) (*MemberAccessLevelResult, *http.Response, error) {
+	arg := struct {
+		File   string         `json:"file"`
+		Member MemberSelector `json:"member"`
+	}{file, member}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/remove_file_member_2", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result fileMemberRemoveActionResult
+	resp, err := s.client.do(ctx, (*http.Request)(req), &result, new(RemoveFileMemberError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	switch {
+	case result.Success != nil:
+		return result.Success, resp, nil
+	case result.MemberError != nil:
+		return nil, resp, result.MemberError
+	}
+	return nil, resp, &FileMemberActionError{Tag: result.Tag}
+}
+
+// ListFileMembersOptions contains the options used to list the members of a
+// shared file.
+type ListFileMembersOptions struct {
+	// If false, the members with access through the parent folders of the
+	// file are not listed. Defaults to true.
+	IncludeInherited *bool `json:"include_inherited,omitempty"`
+
+	// The maximum number of members of each kind returned in the page, or
+	// the server default if zero.
+	Limit uint32 `json:"limit,omitempty"`
+}
+
+// ListFileMembers returns the first page of the members of file, given by
+// path or ID, using the options in opts, if not nil. The next pages are got
+// with ListFileMembersContinue. Errors are returned as an *APIError holding
+// a *SharingFileError.
+func (s *SharingService) ListFileMembers(ctx context.Context, file string, opts *ListFileMembersOptions) (*SharedMembers, *http.Response, error) {
+	arg := struct {
+		File string `json:"file"`
+		ListFileMembersOptions
+	}{File: file}
+	if opts != nil {
+		arg.ListFileMembersOptions = *opts
+	}
+	return s.listMembers(ctx, "2-beta/sharing/list_file_members", &arg, new(SharingFileError))
+}
+
+// ListFileMembersContinue returns the page of shared file members following
+// cursor. Errors are returned as an *APIError holding a *SharingFileError.
+func (s *SharingService) ListFileMembersContinue(ctx context.Context, cursor string) (*SharedMembers, *http.Response, error) {
+	arg := struct {
+		Cursor string `json:"cursor"`
+	}{cursor}
+	return s.listMembers(ctx, "2-beta/sharing/list_file_members/continue", &arg, new(SharingFileError))
+}
+
+// ListFileMembersBatchResult is the result of listing the members of one of
+// the files of ListFileMembersBatch.
+type ListFileMembersBatchResult struct {
+	// The path or ID of the file, as given.
+	File string
+
+	// The first page of the members of the file, if they could be listed.
+	Members *SharedMembers
+
+	// The total number of members of the file, including inherited members.
+	MemberCount uint32
+
+	// The error listing the members of the file, or nil if it succeeded.
+	Err *SharingFileAccessError
+}
+
+type listFileMembersCountResult struct {
+	Members     SharedMembers `json:"members"`
+	MemberCount uint32        `json:"member_count"`
+}
+
+type listFileMembersIndividualResult struct {
+	Tag         string                      `json:".tag"`
+	Result      *listFileMembersCountResult `json:"result"`
+	AccessError *SharingFileAccessError     `json:"access_error"`
+}
+
+var listFileMembersIndividualResultUnion = newUnion("other", map[string]interface{}{
+	"result":       listFileMembersCountResult{},
+	"access_error": SharingFileAccessError{},
+	"other":        nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *listFileMembersIndividualResult) UnmarshalJSON(b []byte) error {
+	return listFileMembersIndividualResultUnion.unmarshal(b, r)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *ListFileMembersBatchResult) UnmarshalJSON(b []byte) error {
+	var v struct {
+		File   string                          `json:"file"`
+		Result listFileMembersIndividualResult `json:"result"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*r = ListFileMembersBatchResult{File: v.File, Err: v.Result.AccessError}
+	switch {
+	case v.Result.Result != nil:
+		r.Members, r.MemberCount = &v.Result.Result.Members, v.Result.Result.MemberCount
+	case r.Err == nil:
+		r.Err = &SharingFileAccessError{Tag: v.Result.Tag}
+	}
+	return nil
+}
+
+// ListFileMembersBatch returns the first page of the members of each of
+// files, given by path or ID, with up to limit members of each kind, or the
+// server default if zero. The results are returned in the order of files.
+// Errors are returned as an *APIError holding a *SharingUserError.
+func (s *SharingService) ListFileMembersBatch(ctx context.Context, files []string, limit uint32) ([]ListFileMembersBatchResult, *http.Response, error) {
+	arg := struct {
+		Files []string `json:"files"`
+		Limit uint32   `json:"limit,omitempty"`
+	}{files, limit}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/list_file_members/batch", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	var results []ListFileMembersBatchResult
+	resp, err := s.client.do(ctx, (*http.Request)(req), &results, new(SharingUserError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return results, resp, nil
+}
+
+// UnshareFile removes all the members of file, given by path or ID. Errors
+// are returned as an *APIError holding a *SharingFileError.
+func (s *SharingService) UnshareFile(ctx context.Context, file string) (*http.Response, error) {
+	arg := struct {
+		File string `json:"file"`
+	}{file}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/unshare_file", &arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.do(ctx, (*http.Request)(req), nil, new(SharingFileError))
+}
+
+// GetFileMetadata returns the sharing metadata of file, given by path or
+// ID. Errors are returned as an *APIError holding a *SharingFileError.
+func (s *SharingService) GetFileMetadata(ctx context.Context, file string) (*SharedFileMetadata, *http.Response, error) {
+	arg := struct {
+		File string `json:"file"`
+	}{file}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/get_file_metadata", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	meta := new(SharedFileMetadata)
+	resp, err := s.client.do(ctx, (*http.Request)(req), meta, new(SharingFileError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return meta, resp, nil
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAddFileMember(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/add_file_member", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"file":"id:3kmLmQFnf1AAAAAAAAAAAw","members":[{".tag":"email","email":"justin@example.com"},{".tag":"email","email":"bad"},{".tag":"email","email":"new"}],` +
+			`"custom_message":"Please review","quiet":false,"access_level":"viewer_no_comment","add_message_as_comment":true}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("add_file_member body is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `[{"member":{".tag":"email","email":"justin@example.com"},"result":{".tag":"success","success":{".tag":"viewer_no_comment"}}},`+
+			`{"member":{".tag":"email","email":"bad"},"result":{".tag":"member_error","member_error":{".tag":"invalid_member"}}},`+
+			`{"member":{".tag":"email","email":"new"},"result":{".tag":"unknown_result"}}]`)
+	})
+
+	results, _, err := client.Sharing.AddFileMember(context.Background(), "id:3kmLmQFnf1AAAAAAAAAAAw",
+		[]MemberSelector{MemberEmail("justin@example.com"), MemberEmail("bad"), MemberEmail("new")},
+		&AddFileMemberOptions{CustomMessage: "Please review", AccessLevel: AccessViewerNoComment, AddMessageAsComment: true})
+	if err != nil {
+		t.Fatalf("AddFileMember returned unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("AddFileMember returned %v results, want 3", len(results))
+	}
+	if got := results[0]; got.Err != nil || got.AccessLevel != AccessViewerNoComment || got.Member.Email != "justin@example.com" {
+		t.Errorf("AddFileMember result 0 is %+v, want a viewer_no_comment success", got)
+	}
+	if got := results[1]; got.Err == nil || got.Err.Tag != "invalid_member" {
+		t.Errorf("AddFileMember result 1 is %+v, want an invalid_member error", got)
+	}
+	if got := results[2]; got.Err == nil || got.Err.Tag != "other" {
+		t.Errorf("AddFileMember result 2 is %+v, want an other error", got)
+	}
+}
+
+func TestRemoveFileMember2(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/remove_file_member_2", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"file":"/report.pdf","member":{".tag":"dropbox_id","dropbox_id":"dbid:a"}}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("remove_file_member_2 body is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{".tag":"success","access_level":{".tag":"viewer"},"warning":"inherited"}`)
+	})
+
+	result, _, err := client.Sharing.RemoveFileMember2(context.Background(), "/report.pdf", MemberDropboxID("dbid:a"))
+	if err != nil {
+		t.Fatalf("RemoveFileMember2 returned unexpected error: %v", err)
+	}
+	want := MemberAccessLevelResult{AccessLevel: AccessViewer, Warning: "inherited"}
+	if *result != want {
+		t.Errorf("RemoveFileMember2 returned %+v, want %+v", *result, want)
+	}
+}
+
+func TestRemoveFileMember2_memberError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/remove_file_member_2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{".tag":"member_error","member_error":{".tag":"access_error","access_error":{".tag":"no_permission"}}}`)
+	})
+
+	_, _, err := client.Sharing.RemoveFileMember2(context.Background(), "/report.pdf", MemberEmail("justin@example.com"))
+	var accessErr *SharingFileAccessError
+	if !errors.As(err, &accessErr) || accessErr.Tag != "no_permission" {
+		t.Errorf("RemoveFileMember2 returned error %#v, want a no_permission *SharingFileAccessError", err)
+	}
+}
+
+func TestListFileMembers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/list_file_members", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"file":"/report.pdf","include_inherited":false,"limit":5}`; got != want {
+			t.Errorf("list_file_members body is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"users":[{"access_type":{".tag":"editor"},"user":{"account_id":"dbid:a"}}],"groups":[],"invitees":[],"cursor":"c1"}`)
+	})
+	mux.HandleFunc("/2-beta/sharing/list_file_members/continue", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"invalid_cursor/..","error":{".tag":"invalid_cursor"}}`)
+	})
+
+	members, _, err := client.Sharing.ListFileMembers(context.Background(), "/report.pdf", &ListFileMembersOptions{
+		IncludeInherited: Bool(false),
+		Limit:            5,
+	})
+	if err != nil {
+		t.Fatalf("ListFileMembers returned unexpected error: %v", err)
+	}
+	if len(members.Users) != 1 || members.Users[0].AccessType != AccessEditor {
+		t.Errorf("ListFileMembers users are %+v, want an editor", members.Users)
+	}
+
+	_, _, err = client.Sharing.ListFileMembersContinue(context.Background(), members.Cursor)
+	var fileErr *SharingFileError
+	if !errors.As(err, &fileErr) || fileErr.Tag != "invalid_cursor" {
+		t.Errorf("ListFileMembersContinue returned error %#v, want an invalid_cursor *SharingFileError", err)
+	}
+}
+
+func TestListFileMembersBatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/list_file_members/batch", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"files":["/a.pdf","/b"],"limit":3}`; got != want {
+			t.Errorf("list_file_members/batch body is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `[{"file":"/a.pdf","result":{".tag":"result","members":{"users":[{"access_type":{".tag":"owner"},"user":{"account_id":"dbid:a"}}],"groups":[],"invitees":[]},"member_count":4}},`+
+			`{"file":"/b","result":{".tag":"access_error","access_error":{".tag":"is_folder"}}}]`)
+	})
+
+	results, _, err := client.Sharing.ListFileMembersBatch(context.Background(), []string{"/a.pdf", "/b"}, 3)
+	if err != nil {
+		t.Fatalf("ListFileMembersBatch returned unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("ListFileMembersBatch returned %v results, want 2", len(results))
+	}
+	if got := results[0]; got.Err != nil || got.MemberCount != 4 || got.Members == nil || len(got.Members.Users) != 1 {
+		t.Errorf("ListFileMembersBatch result 0 is %+v, want 4 members with one user", got)
+	}
+	if got := results[1]; got.File != "/b" || got.Err == nil || got.Err.Tag != "is_folder" {
+		t.Errorf("ListFileMembersBatch result 1 is %+v, want an is_folder error", got)
+	}
+}
+
+func TestUnshareFile_accessError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/unshare_file", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"file":"/report.pdf"}`; got != want {
+			t.Errorf("unshare_file body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"access_error/no_permission/..","error":{".tag":"access_error","access_error":{".tag":"no_permission"}}}`)
+	})
+
+	_, err := client.Sharing.UnshareFile(context.Background(), "/report.pdf")
+	var accessErr *SharingFileAccessError
+	if !errors.As(err, &accessErr) || accessErr.Tag != "no_permission" {
+		t.Errorf("UnshareFile returned error %#v, want a no_permission *SharingFileAccessError", err)
+	}
+}
+
+func TestGetFileMetadata(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/get_file_metadata", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_type":{".tag":"viewer"},"id":"id:3kmLmQFnf1AAAAAAAAAAAw","name":"report.pdf",`+
+			`"policy":{"acl_update_policy":{".tag":"owner"},"shared_link_policy":{".tag":"anyone"}},`+
+			`"preview_url":"https://www.dropbox.com/scl/fi/fir9vjelf","time_invited":"2016-01-20T00:00:00Z"}`)
+	})
+
+	meta, _, err := client.Sharing.GetFileMetadata(context.Background(), "id:3kmLmQFnf1AAAAAAAAAAAw")
+	if err != nil {
+		t.Fatalf("GetFileMetadata returned unexpected error: %v", err)
+	}
+	if meta.AccessType != AccessViewer || meta.Name != "report.pdf" || meta.Policy.ACLUpdatePolicy != "owner" {
+		t.Errorf("GetFileMetadata returned %+v, want the report.pdf viewer metadata", meta)
+	}
+	if meta.TimeInvited == nil || meta.TimeInvited.Year() != 2016 {
+		t.Errorf("GetFileMetadata TimeInvited is %v, want 2016-01-20", meta.TimeInvited)
+	}
+}
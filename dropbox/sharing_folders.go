@@ -0,0 +1,682 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// SharedFolderMetadata contains the metadata of a shared folder.
+type SharedFolderMetadata struct {
+	// The access level of the current user on the folder.
+	AccessType AccessLevel `json:"access_type"`
+
+	// If the folder is inside a team folder.
+	IsInsideTeamFolder bool `json:"is_inside_team_folder"`
+
+	// If the folder is a team folder.
+	IsTeamFolder bool `json:"is_team_folder"`
+
+	// The name of the folder.
+	Name string `json:"name"`
+
+	// The lowercased full path of the folder in the user's Dropbox, if it is
+	// mounted.
+	PathLower string `json:"path_lower,omitempty"`
+
+	// The ID of the shared folder that contains this folder, if any.
+	ParentSharedFolderID string `json:"parent_shared_folder_id,omitempty"`
+
+	// The display names of the owners of the folder.
+	OwnerDisplayNames []string `json:"owner_display_names,omitempty"`
+
+	// The sharing policies of the folder.
+	Policy FolderPolicy `json:"policy"`
+
+	// The URL to preview the folder on the Dropbox website.
+	PreviewURL string `json:"preview_url"`
+
+	// The ID of the shared folder.
+	SharedFolderID string `json:"shared_folder_id"`
+
+	// The time the current user was invited to the folder.
+	TimeInvited time.Time `json:"time_invited"`
+}
+
+// FolderPolicy contains the sharing policies of a shared folder.
+type FolderPolicy struct {
+	// Who can be a member of the folder, as set by its owner. Either "team"
+	// or "anyone".
+	MemberPolicy string `json:"member_policy,omitempty"`
+
+	// Who can be a member of the folder, taking into account the team
+	// policies. Either "team" or "anyone".
+	ResolvedMemberPolicy string `json:"resolved_member_policy,omitempty"`
+
+	// Who can add and remove members. Either "owner" or "editors".
+	ACLUpdatePolicy string `json:"acl_update_policy,omitempty"`
+
+	// Who can use the shared links of the folder. One of "anyone", "team"
+	// or "members".
+	SharedLinkPolicy string `json:"shared_link_policy,omitempty"`
+
+	// If members can see who viewed the files. Either "enabled" or
+	// "disabled".
+	ViewerInfoPolicy string `json:"viewer_info_policy,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The tags of the void unions
+// held by FolderPolicy are decoded as strings.
+func (p *FolderPolicy) UnmarshalJSON(b []byte) error {
+	var v struct {
+		MemberPolicy         tagOnly `json:"member_policy"`
+		ResolvedMemberPolicy tagOnly `json:"resolved_member_policy"`
+		ACLUpdatePolicy      tagOnly `json:"acl_update_policy"`
+		SharedLinkPolicy     tagOnly `json:"shared_link_policy"`
+		ViewerInfoPolicy     tagOnly `json:"viewer_info_policy"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*p = FolderPolicy{
+		MemberPolicy:         string(v.MemberPolicy),
+		ResolvedMemberPolicy: string(v.ResolvedMemberPolicy),
+		ACLUpdatePolicy:      string(v.ACLUpdatePolicy),
+		SharedLinkPolicy:     string(v.SharedLinkPolicy),
+		ViewerInfoPolicy:     string(v.ViewerInfoPolicy),
+	}
+	return nil
+}
+
+// ShareFolderOptions contains the options used to share a folder. Empty
+// policies are set to their defaults.
+type ShareFolderOptions struct {
+	// Who can be a member of the folder. Either "team" or "anyone".
+	MemberPolicy string `json:"member_policy,omitempty"`
+
+	// Who can add and remove members. Either "owner" or "editors".
+	ACLUpdatePolicy string `json:"acl_update_policy,omitempty"`
+
+	// Who can use the shared links of the folder. One of "anyone", "team"
+	// or "members".
+	SharedLinkPolicy string `json:"shared_link_policy,omitempty"`
+
+	// If members can see who viewed the files. Either "enabled" or
+	// "disabled".
+	ViewerInfoPolicy string `json:"viewer_info_policy,omitempty"`
+
+	// If true, the folder is always shared by an asynchronous job.
+	ForceAsync bool `json:"force_async"`
+}
+
+// SharePathError describes why a path could not be shared.
+type SharePathError struct {
+	// One of "is_file", "inside_shared_folder", "contains_shared_folder",
+	// "contains_app_folder", "contains_team_folder", "is_app_folder",
+	// "inside_app_folder", "is_public_folder", "inside_public_folder",
+	// "already_shared", "invalid_path", "is_osx_package",
+	// "inside_osx_package" or "other".
+	Tag string `json:".tag"`
+
+	// The metadata of the existing shared folder. Set when Tag is
+	// "already_shared".
+	AlreadyShared *SharedFolderMetadata `json:"already_shared,omitempty"`
+}
+
+var sharePathErrorUnion = newUnion("other", map[string]interface{}{
+	"is_file":                nil,
+	"inside_shared_folder":   nil,
+	"contains_shared_folder": nil,
+	"contains_app_folder":    nil,
+	"contains_team_folder":   nil,
+	"is_app_folder":          nil,
+	"inside_app_folder":      nil,
+	"is_public_folder":       nil,
+	"inside_public_folder":   nil,
+	"already_shared":         SharedFolderMetadata{},
+	"invalid_path":           nil,
+	"is_osx_package":         nil,
+	"inside_osx_package":     nil,
+	"other":                  nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SharePathError) UnmarshalJSON(b []byte) error {
+	return sharePathErrorUnion.unmarshal(b, e)
+}
+
+func (e *SharePathError) Error() string {
+	return e.Tag
+}
+
+// ShareFolderError describes why a folder could not be shared.
+type ShareFolderError struct {
+	// One of "email_unverified", "bad_path",
+	// "team_policy_disallows_member_policy", "disallowed_shared_link_policy",
+	// "no_permission" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "bad_path".
+	BadPath *SharePathError `json:"bad_path,omitempty"`
+}
+
+var shareFolderErrorUnion = newUnion("other", map[string]interface{}{
+	"email_unverified":                    nil,
+	"bad_path":                            SharePathError{},
+	"team_policy_disallows_member_policy": nil,
+	"disallowed_shared_link_policy":       nil,
+	"no_permission":                       nil,
+	"other":                               nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ShareFolderError) UnmarshalJSON(b []byte) error {
+	return shareFolderErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the path error, if any.
+func (e *ShareFolderError) Unwrap() error {
+	if e.BadPath == nil {
+		return nil
+	}
+	return e.BadPath
+}
+
+func (e *ShareFolderError) Error() string {
+	if e.BadPath != nil {
+		return "share folder failed: bad_path/" + e.BadPath.Error()
+	}
+	return "share folder failed: " + e.Tag
+}
+
+// shareFolderJobStatus is either the launch result of sharing a folder or
+// the result of checking its status.
+type shareFolderJobStatus struct {
+	// One of "async_job_id", "in_progress", "complete", "failed" or "other".
+	Tag        string                `json:".tag"`
+	AsyncJobID string                `json:"async_job_id"`
+	Complete   *SharedFolderMetadata `json:"complete"`
+	Failed     *ShareFolderError     `json:"failed"`
+}
+
+var shareFolderJobStatusUnion = newUnion("other", map[string]interface{}{
+	"async_job_id": "",
+	"in_progress":  nil,
+	"complete":     SharedFolderMetadata{},
+	"failed":       ShareFolderError{},
+	"other":        nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *shareFolderJobStatus) UnmarshalJSON(b []byte) error {
+	return shareFolderJobStatusUnion.unmarshal(b, s)
+}
+
+func (s *shareFolderJobStatus) Pending() bool {
+	return s.Tag == "async_job_id" || s.Tag == "in_progress"
+}
+
+// ShareFolder shares the folder at path, using the options in opts, if not
+// nil, and returns the metadata of the new shared folder. Sharing large
+// folders runs as an asynchronous job, which is awaited checking its status
+// as configured by the PollPolicy of the client. Errors are returned as an
+// *APIError holding a *ShareFolderError, or as a *ShareFolderError if the
+// job fails.
+func (s *SharingService) ShareFolder(ctx context.Context, path string, opts *ShareFolderOptions) (*SharedFolderMetadata, *http.Response, error) {
+	arg := struct {
+		Path string `json:"path"`
+		ShareFolderOptions
+	}{Path: path}
+	if opts != nil {
+		arg.ShareFolderOptions = *opts
+	}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/share_folder", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var status shareFolderJobStatus
+	resp, err := s.client.do(ctx, (*http.Request)(req), &status, new(ShareFolderError))
+	if err != nil {
+		return nil, resp, err
+	}
+	checkResp, err := s.client.WaitAsyncJob(ctx, "2-beta/sharing/check_share_job_status", status.AsyncJobID, &status, nil)
+	if checkResp != nil {
+		resp = checkResp
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+
+	switch {
+	case status.Complete != nil:
+		return status.Complete, resp, nil
+	case status.Failed != nil:
+		return nil, resp, status.Failed
+	}
+	return nil, resp, &ShareFolderError{Tag: status.Tag}
+}
+
+// UnshareFolderError describes why a folder could not be unshared.
+type UnshareFolderError struct {
+	// One of "access_error", "team_folder", "no_permission",
+	// "too_many_files" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "access_error".
+	AccessError *SharedFolderAccessError `json:"access_error,omitempty"`
+}
+
+var unshareFolderErrorUnion = newUnion("other", map[string]interface{}{
+	"access_error":   SharedFolderAccessError{},
+	"team_folder":    nil,
+	"no_permission":  nil,
+	"too_many_files": nil,
+	"other":          nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *UnshareFolderError) UnmarshalJSON(b []byte) error {
+	return unshareFolderErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the access error, if any.
+func (e *UnshareFolderError) Unwrap() error {
+	if e.AccessError == nil {
+		return nil
+	}
+	return e.AccessError
+}
+
+func (e *UnshareFolderError) Error() string {
+	if e.AccessError != nil {
+		return "unshare folder failed: access_error/" + e.AccessError.Error()
+	}
+	return "unshare folder failed: " + e.Tag
+}
+
+// JobError describes why a sharing job failed.
+type JobError struct {
+	// One of "unshare_folder_error", "remove_folder_member_error",
+	// "relinquish_folder_membership_error" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "unshare_folder_error".
+	UnshareFolderError *UnshareFolderError `json:"unshare_folder_error,omitempty"`
+
+	// Set when Tag is "remove_folder_member_error".
+	RemoveFolderMemberError *RemoveFolderMemberError `json:"remove_folder_member_error,omitempty"`
+}
+
+var jobErrorUnion = newUnion("other", map[string]interface{}{
+	"unshare_folder_error":               UnshareFolderError{},
+	"remove_folder_member_error":         RemoveFolderMemberError{},
+	"relinquish_folder_membership_error": nil,
+	"other":                              nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *JobError) UnmarshalJSON(b []byte) error {
+	return jobErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the error of the job operation, if any.
+func (e *JobError) Unwrap() error {
+	switch {
+	case e.UnshareFolderError != nil:
+		return e.UnshareFolderError
+	case e.RemoveFolderMemberError != nil:
+		return e.RemoveFolderMemberError
+	}
+	return nil
+}
+
+func (e *JobError) Error() string {
+	if err := e.Unwrap(); err != nil {
+		return err.Error()
+	}
+	return "sharing job failed: " + e.Tag
+}
+
+// sharingJobStatus is either the launch result of a sharing job without
+// result or the result of checking its status.
+type sharingJobStatus struct {
+	// One of "async_job_id", "in_progress", "complete", "failed" or "other".
+	Tag        string    `json:".tag"`
+	AsyncJobID string    `json:"async_job_id"`
+	Failed     *JobError `json:"failed"`
+}
+
+var sharingJobStatusUnion = newUnion("other", map[string]interface{}{
+	"async_job_id": "",
+	"in_progress":  nil,
+	"complete":     nil,
+	"failed":       JobError{},
+	"other":        nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *sharingJobStatus) UnmarshalJSON(b []byte) error {
+	return sharingJobStatusUnion.unmarshal(b, s)
+}
+
+func (s *sharingJobStatus) Pending() bool {
+	return s.Tag == "async_job_id" || s.Tag == "in_progress"
+}
+
+// UnshareFolder stops sharing the shared folder sharedFolderID, and waits
+// for the job to complete, checking its status as configured by the
+// PollPolicy of the client. If leaveACopy is true, the members keep a copy of
+// the folder contents. Errors are returned as an *APIError holding an
+// *UnshareFolderError, or as a *JobError if the job fails.
+func (s *SharingService) UnshareFolder(ctx context.Context, sharedFolderID string, leaveACopy bool) (*http.Response, error) {
+	arg := struct {
+		SharedFolderID string `json:"shared_folder_id"`
+		LeaveACopy     bool   `json:"leave_a_copy"`
+	}{sharedFolderID, leaveACopy}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/unshare_folder", &arg)
+	if err != nil {
+		return nil, err
+	}
+
+	var status sharingJobStatus
+	resp, err := s.client.do(ctx, (*http.Request)(req), &status, new(UnshareFolderError))
+	if err != nil {
+		return resp, err
+	}
+	checkResp, err := s.client.WaitAsyncJob(ctx, "2-beta/sharing/check_job_status", status.AsyncJobID, &status, nil)
+	if checkResp != nil {
+		resp = checkResp
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	switch {
+	case status.Tag == "complete":
+		return resp, nil
+	case status.Failed != nil:
+		return resp, status.Failed
+	}
+	return resp, &JobError{Tag: status.Tag}
+}
+
+// ListFoldersContinueError describes why a shared folder listing could not
+// be continued.
+type ListFoldersContinueError struct {
+	// Either "invalid_cursor" or "other".
+	Tag string `json:".tag"`
+}
+
+var listFoldersContinueErrorUnion = newUnion("other", map[string]interface{}{
+	"invalid_cursor": nil,
+	"other":          nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ListFoldersContinueError) UnmarshalJSON(b []byte) error {
+	return listFoldersContinueErrorUnion.unmarshal(b, e)
+}
+
+func (e *ListFoldersContinueError) Error() string {
+	return "list folders failed: " + e.Tag
+}
+
+// SharedFoldersIterator iterates over shared folders, fetching them page by
+// page as needed. Use it as follows:
+//
+//	it := c.Sharing.ListFolders(ctx, 0)
+//	for it.Next() {
+//		folder := it.Folder()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type SharedFoldersIterator struct {
+	ctx         context.Context
+	s           *SharingService
+	listURL     string
+	continueURL string
+	limit       uint32
+	cursor      string
+	hasMore     bool
+	folders     []SharedFolderMetadata
+	current     *SharedFolderMetadata
+	resp        *http.Response
+	err         error
+}
+
+// ListFolders returns an iterator over the shared folders the user is a
+// member of, fetched in pages of up to limit folders, or the server default
+// if zero.
+func (s *SharingService) ListFolders(ctx context.Context, limit uint32) *SharedFoldersIterator {
+	return &SharedFoldersIterator{
+		ctx: ctx, s: s, limit: limit, hasMore: true,
+		listURL:     "2-beta/sharing/list_folders",
+		continueURL: "2-beta/sharing/list_folders/continue",
+	}
+}
+
+// ListFoldersContinue returns an iterator over the shared folders listed
+// after cursor, which must be a cursor returned by the Cursor method of an
+// iterator returned by ListFolders. Errors are returned as an *APIError
+// holding a *ListFoldersContinueError, or as ErrEmptyCursor if cursor is
+// empty.
+func (s *SharingService) ListFoldersContinue(ctx context.Context, cursor string) *SharedFoldersIterator {
+	if cursor == "" {
+		return &SharedFoldersIterator{err: ErrEmptyCursor}
+	}
+	it := s.ListFolders(ctx, 0)
+	it.cursor = cursor
+	return it
+}
+
+// ListMountableFolders returns an iterator over the shared folders the user
+// can mount, fetched in pages of up to limit folders, or the server default
+// if zero.
+func (s *SharingService) ListMountableFolders(ctx context.Context, limit uint32) *SharedFoldersIterator {
+	return &SharedFoldersIterator{
+		ctx: ctx, s: s, limit: limit, hasMore: true,
+		listURL:     "2-beta/sharing/list_mountable_folders",
+		continueURL: "2-beta/sharing/list_mountable_folders/continue",
+	}
+}
+
+// ListMountableFoldersContinue returns an iterator over the mountable shared
+// folders listed after cursor, which must be a cursor returned by the Cursor
+// method of an iterator returned by ListMountableFolders. Errors are
+// returned as an *APIError holding a *ListFoldersContinueError, or as
+// ErrEmptyCursor if cursor is empty.
+func (s *SharingService) ListMountableFoldersContinue(ctx context.Context, cursor string) *SharedFoldersIterator {
+	if cursor == "" {
+		return &SharedFoldersIterator{err: ErrEmptyCursor}
+	}
+	it := s.ListMountableFolders(ctx, 0)
+	it.cursor = cursor
+	return it
+}
+
+// Next advances the iterator to the next folder, which is then available
+// through the Folder method. It returns false when there are no more
+// folders or an error happens.
+func (it *SharedFoldersIterator) Next() bool {
+	for len(it.folders) == 0 {
+		if it.err != nil || !it.hasMore {
+			it.current = nil
+			return false
+		}
+		it.fetch()
+	}
+	it.current, it.folders = &it.folders[0], it.folders[1:]
+	return true
+}
+
+// Folder returns the current folder.
+func (it *SharedFoldersIterator) Folder() *SharedFolderMetadata {
+	return it.current
+}
+
+// Cursor returns the cursor of the last page fetched, which can be used to
+// resume the listing.
+func (it *SharedFoldersIterator) Cursor() string {
+	return it.cursor
+}
+
+// Err returns the error, if any, that happened while iterating.
+func (it *SharedFoldersIterator) Err() error {
+	return it.err
+}
+
+// Response returns the HTTP response of the last page fetched.
+func (it *SharedFoldersIterator) Response() *http.Response {
+	return it.resp
+}
+
+func (it *SharedFoldersIterator) fetch() {
+	var req *RPCRequest
+	if it.cursor == "" {
+		arg := struct {
+			Limit uint32 `json:"limit,omitempty"`
+		}{it.limit}
+		req, it.err = it.s.client.NewRPCRequest("POST", it.listURL, &arg)
+	} else {
+		arg := struct {
+			Cursor string `json:"cursor"`
+		}{it.cursor}
+		req, it.err = it.s.client.NewRPCRequest("POST", it.continueURL, &arg)
+	}
+	if it.err != nil {
+		return
+	}
+	markIdempotent((*http.Request)(req))
+
+	var result struct {
+		Entries []SharedFolderMetadata `json:"entries"`
+		Cursor  string                 `json:"cursor"`
+	}
+	it.resp, it.err = it.s.client.do(it.ctx, (*http.Request)(req), &result, new(ListFoldersContinueError))
+	if it.err != nil {
+		return
+	}
+	it.folders = result.Entries
+	it.cursor = result.Cursor
+	it.hasMore = result.Cursor != ""
+}
+
+// MountFolderError describes why a shared folder could not be mounted.
+type MountFolderError struct {
+	// One of "access_error", "inside_shared_folder", "insufficient_quota",
+	// "already_mounted", "no_permission", "not_mountable" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "access_error".
+	AccessError *SharedFolderAccessError `json:"access_error,omitempty"`
+}
+
+var mountFolderErrorUnion = newUnion("other", map[string]interface{}{
+	"access_error":         SharedFolderAccessError{},
+	"inside_shared_folder": nil,
+	"insufficient_quota":   nil,
+	"already_mounted":      nil,
+	"no_permission":        nil,
+	"not_mountable":        nil,
+	"other":                nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *MountFolderError) UnmarshalJSON(b []byte) error {
+	return mountFolderErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the access error, if any.
+func (e *MountFolderError) Unwrap() error {
+	if e.AccessError == nil {
+		return nil
+	}
+	return e.AccessError
+}
+
+func (e *MountFolderError) Error() string {
+	if e.AccessError != nil {
+		return "mount folder failed: access_error/" + e.AccessError.Error()
+	}
+	return "mount folder failed: " + e.Tag
+}
+
+// MountFolder mounts the shared folder sharedFolderID in the user's
+// Dropbox, and returns its metadata. Errors are returned as an *APIError
+// holding a *MountFolderError.
+func (s *SharingService) MountFolder(ctx context.Context, sharedFolderID string) (*SharedFolderMetadata, *http.Response, error) {
+	arg := struct {
+		SharedFolderID string `json:"shared_folder_id"`
+	}{sharedFolderID}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/mount_folder", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	folder := new(SharedFolderMetadata)
+	resp, err := s.client.do(ctx, (*http.Request)(req), folder, new(MountFolderError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return folder, resp, nil
+}
+
+// UnmountFolderError describes why a shared folder could not be unmounted.
+type UnmountFolderError struct {
+	// One of "access_error", "no_permission", "not_unmountable" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "access_error".
+	AccessError *SharedFolderAccessError `json:"access_error,omitempty"`
+}
+
+var unmountFolderErrorUnion = newUnion("other", map[string]interface{}{
+	"access_error":    SharedFolderAccessError{},
+	"no_permission":   nil,
+	"not_unmountable": nil,
+	"other":           nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *UnmountFolderError) UnmarshalJSON(b []byte) error {
+	return unmountFolderErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the access error, if any.
+func (e *UnmountFolderError) Unwrap() error {
+	if e.AccessError == nil {
+		return nil
+	}
+	return e.AccessError
+}
+
+func (e *UnmountFolderError) Error() string {
+	if e.AccessError != nil {
+		return "unmount folder failed: access_error/" + e.AccessError.Error()
+	}
+	return "unmount folder failed: " + e.Tag
+}
+
+// UnmountFolder unmounts the shared folder sharedFolderID from the user's
+// Dropbox, without leaving it. Errors are returned as an *APIError holding
+// an *UnmountFolderError.
+func (s *SharingService) UnmountFolder(ctx context.Context, sharedFolderID string) (*http.Response, error) {
+	arg := struct {
+		SharedFolderID string `json:"shared_folder_id"`
+	}{sharedFolderID}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/unmount_folder", &arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.do(ctx, (*http.Request)(req), nil, new(UnmountFolderError))
+}
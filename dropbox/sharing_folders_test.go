@@ -0,0 +1,216 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testSharedFolder = `"access_type":{".tag":"owner"},"is_inside_team_folder":false,"is_team_folder":false,` +
+	`"name":"dir","path_lower":"/dir","policy":{"acl_update_policy":{".tag":"owner"},"shared_link_policy":{".tag":"anyone"},` +
+	`"member_policy":{".tag":"anyone"},"resolved_member_policy":{".tag":"team"}},"preview_url":"https://www.dropbox.com/scl/fo/fir9vjelf",` +
+	`"shared_folder_id":"84528192421","time_invited":"2016-01-20T00:00:00Z"`
+
+func TestShareFolder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/share_folder", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"path":"/dir","member_policy":"team","acl_update_policy":"editors","force_async":true}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("share_folder body is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{".tag":"async_job_id","async_job_id":"job"}`)
+	})
+	checks := 0
+	mux.HandleFunc("/2-beta/sharing/check_share_job_status", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"async_job_id":"job"}`; got != want {
+			t.Errorf("check_share_job_status body is %v, want %v", got, want)
+		}
+		checks++
+		if checks == 1 {
+			fmt.Fprint(w, `{".tag":"in_progress"}`)
+			return
+		}
+		fmt.Fprint(w, `{".tag":"complete",`+testSharedFolder+`}`)
+	})
+
+	folder, _, err := client.Sharing.ShareFolder(context.Background(), "/dir", &ShareFolderOptions{
+		MemberPolicy:    "team",
+		ACLUpdatePolicy: "editors",
+		ForceAsync:      true,
+	})
+	if err != nil {
+		t.Fatalf("ShareFolder returned unexpected error: %v", err)
+	}
+	if got, want := folder.SharedFolderID, "84528192421"; got != want {
+		t.Errorf("ShareFolder SharedFolderID is %v, want %v", got, want)
+	}
+	if got, want := folder.AccessType, AccessOwner; got != want {
+		t.Errorf("ShareFolder AccessType is %v, want %v", got, want)
+	}
+	want := FolderPolicy{
+		MemberPolicy:         "anyone",
+		ResolvedMemberPolicy: "team",
+		ACLUpdatePolicy:      "owner",
+		SharedLinkPolicy:     "anyone",
+	}
+	if folder.Policy != want {
+		t.Errorf("ShareFolder Policy is %+v, want %+v", folder.Policy, want)
+	}
+}
+
+func TestShareFolder_alreadyShared(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/share_folder", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"bad_path/already_shared/..","error":{".tag":"bad_path","bad_path":{".tag":"already_shared",`+testSharedFolder+`}}}`)
+	})
+
+	_, _, err := client.Sharing.ShareFolder(context.Background(), "/dir", nil)
+	var pathErr *SharePathError
+	if !errors.As(err, &pathErr) || pathErr.Tag != "already_shared" {
+		t.Fatalf("ShareFolder returned error %#v, want an already_shared *SharePathError", err)
+	}
+	if pathErr.AlreadyShared == nil || pathErr.AlreadyShared.SharedFolderID != "84528192421" {
+		t.Errorf("SharePathError.AlreadyShared is %+v, want the shared folder", pathErr.AlreadyShared)
+	}
+}
+
+func TestUnshareFolder_failed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/unshare_folder", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"shared_folder_id":"84528192421","leave_a_copy":true}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("unshare_folder body is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{".tag":"async_job_id","async_job_id":"job"}`)
+	})
+	mux.HandleFunc("/2-beta/sharing/check_job_status", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{".tag":"failed","failed":{".tag":"unshare_folder_error","unshare_folder_error":{".tag":"too_many_files"}}}`)
+	})
+
+	_, err := client.Sharing.UnshareFolder(context.Background(), "84528192421", true)
+	var unshareErr *UnshareFolderError
+	if !errors.As(err, &unshareErr) || unshareErr.Tag != "too_many_files" {
+		t.Errorf("UnshareFolder returned error %#v, want a too_many_files *UnshareFolderError", err)
+	}
+}
+
+func TestListFolders(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/list_folders", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"limit":1}`; got != want {
+			t.Errorf("list_folders body is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"entries":[{"name":"a","shared_folder_id":"1"}],"cursor":"c1"}`)
+	})
+	mux.HandleFunc("/2-beta/sharing/list_folders/continue", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"cursor":"c1"}`; got != want {
+			t.Errorf("list_folders/continue body is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"entries":[{"name":"b","shared_folder_id":"2"}]}`)
+	})
+
+	var got []string
+	it := client.Sharing.ListFolders(context.Background(), 1)
+	for it.Next() {
+		got = append(got, it.Folder().Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("ListFolders returned unexpected error: %v", err)
+	}
+	if want := []string{"a", "b"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ListFolders folders are %v, want %v", got, want)
+	}
+}
+
+func TestListMountableFoldersContinue_invalidCursor(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/list_mountable_folders/continue", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"invalid_cursor/..","error":{".tag":"invalid_cursor"}}`)
+	})
+
+	it := client.Sharing.ListMountableFoldersContinue(context.Background(), "stale")
+	if it.Next() {
+		t.Fatal("ListMountableFoldersContinue.Next returned true on error")
+	}
+	var listErr *ListFoldersContinueError
+	if !errors.As(it.Err(), &listErr) || listErr.Tag != "invalid_cursor" {
+		t.Errorf("ListMountableFoldersContinue.Err is %#v, want an invalid_cursor *ListFoldersContinueError", it.Err())
+	}
+}
+
+func TestMountFolder_accessError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/mount_folder", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"access_error/not_a_member/..","error":{".tag":"access_error","access_error":{".tag":"not_a_member"}}}`)
+	})
+
+	_, _, err := client.Sharing.MountFolder(context.Background(), "84528192421")
+	var accessErr *SharedFolderAccessError
+	if !errors.As(err, &accessErr) || accessErr.Tag != "not_a_member" {
+		t.Errorf("MountFolder returned error %#v, want a not_a_member *SharedFolderAccessError", err)
+	}
+}
+
+func TestUnshareFolder_unknownStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/unshare_folder", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{".tag":"async_job_id","async_job_id":"job"}`)
+	})
+	mux.HandleFunc("/2-beta/sharing/check_job_status", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{".tag":"unknown_status"}`)
+	})
+
+	_, err := client.Sharing.UnshareFolder(context.Background(), "84528192421", false)
+	var jobErr *JobError
+	if !errors.As(err, &jobErr) || jobErr.Tag != "other" {
+		t.Errorf("UnshareFolder returned error %#v, want an other *JobError", err)
+	}
+}
+
+func TestListFoldersContinue_emptyCursor(t *testing.T) {
+	c := NewClient(nil)
+	for name, it := range map[string]*SharedFoldersIterator{
+		"ListFoldersContinue":          c.Sharing.ListFoldersContinue(context.Background(), ""),
+		"ListMountableFoldersContinue": c.Sharing.ListMountableFoldersContinue(context.Background(), ""),
+	} {
+		if it.Next() {
+			t.Errorf("%s.Next returned true with an empty cursor", name)
+		}
+		if !errors.Is(it.Err(), ErrEmptyCursor) {
+			t.Errorf("%s.Err is %v, want %v", name, it.Err(), ErrEmptyCursor)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// SharedLinkOptions contains the options used to access the content of a
+// shared link.
+type SharedLinkOptions struct {
+	// If the link is to a folder, the path of a file or folder inside it,
+	// relative to the linked folder.
+	Path string `json:"path,omitempty"`
+
+	// The password of the link, if it requires one.
+	LinkPassword string `json:"link_password,omitempty"`
+}
+
+// GetSharedLinkMetadata returns the metadata of the file or folder linked
+// by url, using the options in opts, if not nil. Links of other users can be
+// used. Errors are returned as an *APIError holding a *SharedLinkError.
+func (s *SharingService) GetSharedLinkMetadata(ctx context.Context, url string, opts *SharedLinkOptions) (SharedLinkMetadata, *http.Response, error) {
+	arg := struct {
+		URL string `json:"url"`
+		SharedLinkOptions
+	}{URL: url}
+	if opts != nil {
+		arg.SharedLinkOptions = *opts
+	}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/get_shared_link_metadata", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	var link sharedLinkMetadataValue
+	resp, err := s.client.do(ctx, (*http.Request)(req), &link, new(SharedLinkError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return link.SharedLinkMetadata, resp, nil
+}
+
+// GetSharedLinkFile downloads the file linked by url, or the file at
+// opts.Path inside the linked folder. The returned body streams the file
+// content and it must be closed by the caller. Errors are returned as an
+// *APIError holding a *SharedLinkError, whose Tag is
+// "shared_link_is_directory" if the link is to a folder and no file is
+// selected.
+func (s *SharingService) GetSharedLinkFile(ctx context.Context, url string, opts *SharedLinkOptions) (SharedLinkMetadata, io.ReadCloser, *http.Response, error) {
+	arg := struct {
+		URL string `json:"url"`
+		SharedLinkOptions
+	}{URL: url}
+	if opts != nil {
+		arg.SharedLinkOptions = *opts
+	}
+	req, err := s.client.NewDownloadRequest("POST", "2-beta/sharing/get_shared_link_file", &arg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	var link sharedLinkMetadataValue
+	body, resp, err := s.client.doDownload(ctx, req, &link, new(SharedLinkError))
+	if err != nil {
+		return nil, nil, resp, err
+	}
+
+	return link.SharedLinkMetadata, body, resp, nil
+}
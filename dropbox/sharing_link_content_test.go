@@ -0,0 +1,88 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetSharedLinkMetadata(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/get_shared_link_metadata", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"url":"https://db.tt/a","path":"/b.txt","link_password":"secret"}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("get_shared_link_metadata body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{".tag":"file","url":"https://db.tt/a","name":"b.txt","rev":"1","size":3}`)
+	})
+
+	link, _, err := client.Sharing.GetSharedLinkMetadata(context.Background(), "https://db.tt/a", &SharedLinkOptions{Path: "/b.txt", LinkPassword: "secret"})
+	if err != nil {
+		t.Fatalf("GetSharedLinkMetadata returned unexpected error: %v", err)
+	}
+	file, ok := link.(*FileLinkMetadata)
+	if !ok {
+		t.Fatalf("GetSharedLinkMetadata returned %T, want *FileLinkMetadata", link)
+	}
+	if got, want := file.Name, "b.txt"; got != want {
+		t.Errorf("GetSharedLinkMetadata Name is %v, want %v", got, want)
+	}
+}
+
+func TestGetSharedLinkFile(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/get_shared_link_file", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Dropbox-API-Arg"), `{"url":"https://db.tt/a"}`; got != want {
+			t.Errorf("Dropbox-API-Arg Header is %v, want %v", got, want)
+		}
+		w.Header().Set("Dropbox-API-Result", `{".tag":"file","url":"https://db.tt/a","name":"a.txt","size":5}`)
+		fmt.Fprint(w, "hello")
+	})
+
+	link, body, _, err := client.Sharing.GetSharedLinkFile(context.Background(), "https://db.tt/a", nil)
+	if err != nil {
+		t.Fatalf("GetSharedLinkFile returned unexpected error: %v", err)
+	}
+	defer body.Close()
+	data, _ := ioutil.ReadAll(body)
+	if got, want := string(data), "hello"; got != want {
+		t.Errorf("GetSharedLinkFile body is %v, want %v", got, want)
+	}
+	if got, want := link.GetName(), "a.txt"; got != want {
+		t.Errorf("GetSharedLinkFile Name is %v, want %v", got, want)
+	}
+}
+
+func TestGetSharedLinkFile_directory(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/get_shared_link_file", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"shared_link_is_directory/..","error":{".tag":"shared_link_is_directory"}}`)
+	})
+
+	_, body, _, err := client.Sharing.GetSharedLinkFile(context.Background(), "https://db.tt/a", nil)
+	if body != nil {
+		t.Error("GetSharedLinkFile returned a body on error")
+	}
+	var linkErr *SharedLinkError
+	if !errors.As(err, &linkErr) || linkErr.Tag != "shared_link_is_directory" {
+		t.Errorf("GetSharedLinkFile error is %#v, want a shared_link_is_directory *SharedLinkError", err)
+	}
+}
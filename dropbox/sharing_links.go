@@ -0,0 +1,605 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// SharedLinkMetadata is the interface implemented by the metadata of shared
+// links: *FileLinkMetadata and *FolderLinkMetadata.
+type SharedLinkMetadata interface {
+	// GetURL returns the URL of the link.
+	GetURL() string
+
+	// GetName returns the name of the linked file or folder.
+	GetName() string
+
+	// GetPathLower returns the lowercased full path of the linked file or
+	// folder in the user's Dropbox, if the user has access to it.
+	GetPathLower() string
+}
+
+// FileLinkMetadata contains the metadata of a shared link to a file.
+type FileLinkMetadata struct {
+	// The URL of the link.
+	URL string `json:"url"`
+
+	// A unique identifier for the linked file.
+	ID string `json:"id,omitempty"`
+
+	// The name of the linked file.
+	Name string `json:"name"`
+
+	// The time the link expires, if any.
+	Expires *time.Time `json:"expires,omitempty"`
+
+	// The lowercased full path in the user's Dropbox, if the user has access
+	// to the file.
+	PathLower string `json:"path_lower,omitempty"`
+
+	// The permissions of the user on the link.
+	LinkPermissions *LinkPermissions `json:"link_permissions,omitempty"`
+
+	// The modification time set by the desktop client when the file was
+	// added to Dropbox.
+	ClientModified time.Time `json:"client_modified"`
+
+	// The last time the file was modified on Dropbox.
+	ServerModified time.Time `json:"server_modified"`
+
+	// A unique identifier for the current revision of the file.
+	Rev string `json:"rev"`
+
+	// The file size in bytes.
+	Size uint64 `json:"size"`
+}
+
+// FolderLinkMetadata contains the metadata of a shared link to a folder.
+type FolderLinkMetadata struct {
+	// The URL of the link.
+	URL string `json:"url"`
+
+	// A unique identifier for the linked folder.
+	ID string `json:"id,omitempty"`
+
+	// The name of the linked folder.
+	Name string `json:"name"`
+
+	// The time the link expires, if any.
+	Expires *time.Time `json:"expires,omitempty"`
+
+	// The lowercased full path in the user's Dropbox, if the user has access
+	// to the folder.
+	PathLower string `json:"path_lower,omitempty"`
+
+	// The permissions of the user on the link.
+	LinkPermissions *LinkPermissions `json:"link_permissions,omitempty"`
+}
+
+// GetURL returns the URL of the link.
+func (m *FileLinkMetadata) GetURL() string { return m.URL }
+
+// GetName returns the name of the linked file or folder.
+func (m *FileLinkMetadata) GetName() string { return m.Name }
+
+// GetPathLower returns the lowercased full path of the linked file or folder
+// in the user's Dropbox, if the user has access to it.
+func (m *FileLinkMetadata) GetPathLower() string { return m.PathLower }
+
+// GetURL returns the URL of the link.
+func (m *FolderLinkMetadata) GetURL() string { return m.URL }
+
+// GetName returns the name of the linked file or folder.
+func (m *FolderLinkMetadata) GetName() string { return m.Name }
+
+// GetPathLower returns the lowercased full path of the linked file or folder
+// in the user's Dropbox, if the user has access to it.
+func (m *FolderLinkMetadata) GetPathLower() string { return m.PathLower }
+
+var sharedLinkMetadataUnion = newUnion("", map[string]interface{}{
+	"file":   FileLinkMetadata{},
+	"folder": FolderLinkMetadata{},
+})
+
+// decodeSharedLinkMetadata decodes a JSON encoded SharedLinkMetadata using
+// its ".tag" field.
+func decodeSharedLinkMetadata(b []byte) (SharedLinkMetadata, error) {
+	_, m, err := sharedLinkMetadataUnion.decode(b)
+	if err != nil {
+		return nil, err
+	}
+	return m.(SharedLinkMetadata), nil
+}
+
+// sharedLinkMetadataValue is a SharedLinkMetadata that can be JSON decoded.
+type sharedLinkMetadataValue struct {
+	SharedLinkMetadata
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *sharedLinkMetadataValue) UnmarshalJSON(b []byte) error {
+	m, err := decodeSharedLinkMetadata(b)
+	if err != nil {
+		return err
+	}
+	v.SharedLinkMetadata = m
+	return nil
+}
+
+// LinkPermissions contains the permissions of the user on a shared link.
+type LinkPermissions struct {
+	// The visibility of the link, as seen by the user. One of "public",
+	// "team_only", "password", "team_and_password", "shared_folder_only",
+	// "no_one", "only_you" or "other".
+	ResolvedVisibility string `json:"resolved_visibility,omitempty"`
+
+	// The visibility requested by the link owner. One of "public",
+	// "team_only", "password" or "other".
+	RequestedVisibility string `json:"requested_visibility,omitempty"`
+
+	// If the user can revoke the link.
+	CanRevoke bool `json:"can_revoke"`
+
+	// Why the user cannot revoke the link, if CanRevoke is false. One of
+	// "shared_link_malformed", "shared_link_not_found",
+	// "shared_link_access_denied", "unsupported_link_type" or "other".
+	RevokeFailureReason string `json:"revoke_failure_reason,omitempty"`
+
+	// Who can use the link. One of "public", "team", "no_one", "password",
+	// "members" or "other".
+	EffectiveAudience string `json:"effective_audience,omitempty"`
+
+	// The access level granted by the link. One of "viewer", "editor",
+	// "max" or "other".
+	LinkAccessLevel string `json:"link_access_level,omitempty"`
+
+	// If the linked content can be downloaded.
+	AllowDownload bool `json:"allow_download"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The tags of the void unions
+// held by LinkPermissions are decoded as strings.
+func (p *LinkPermissions) UnmarshalJSON(b []byte) error {
+	type linkPermissions LinkPermissions
+	v := struct {
+		*linkPermissions
+		ResolvedVisibility  tagOnly `json:"resolved_visibility"`
+		RequestedVisibility tagOnly `json:"requested_visibility"`
+		RevokeFailureReason tagOnly `json:"revoke_failure_reason"`
+		EffectiveAudience   tagOnly `json:"effective_audience"`
+		LinkAccessLevel     tagOnly `json:"link_access_level"`
+	}{linkPermissions: (*linkPermissions)(p)}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	p.ResolvedVisibility = string(v.ResolvedVisibility)
+	p.RequestedVisibility = string(v.RequestedVisibility)
+	p.RevokeFailureReason = string(v.RevokeFailureReason)
+	p.EffectiveAudience = string(v.EffectiveAudience)
+	p.LinkAccessLevel = string(v.LinkAccessLevel)
+	return nil
+}
+
+// SharedLinkSettings contains the settings of a shared link. Empty fields
+// are not changed or are set to their defaults.
+type SharedLinkSettings struct {
+	// The requested visibility of the link. One of "public", "team_only" or
+	// "password".
+	RequestedVisibility string `json:"requested_visibility,omitempty"`
+
+	// The password required to use the link, if RequestedVisibility is
+	// "password".
+	LinkPassword string `json:"link_password,omitempty"`
+
+	// The time the link expires. If zero, the link does not expire.
+	Expires time.Time `json:"-"`
+
+	// Who can use the link. One of "public", "team", "no_one", "password" or
+	// "members".
+	Audience string `json:"audience,omitempty"`
+
+	// The access level granted by the link. One of "viewer", "editor" or
+	// "max".
+	Access string `json:"access,omitempty"`
+
+	// If set, whether the linked content can be downloaded.
+	AllowDownload *bool `json:"allow_download,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. Dropbox only accepts timestamps
+// in UTC and without fractional seconds.
+func (s SharedLinkSettings) MarshalJSON() ([]byte, error) {
+	type sharedLinkSettings SharedLinkSettings
+	v := struct {
+		sharedLinkSettings
+		Expires string `json:"expires,omitempty"`
+	}{sharedLinkSettings: sharedLinkSettings(s)}
+	if !s.Expires.IsZero() {
+		v.Expires = s.Expires.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	return json.Marshal(v)
+}
+
+// SharedLinkSettingsError describes why the settings of a shared link were
+// rejected.
+type SharedLinkSettingsError struct {
+	// One of "invalid_settings", "not_authorized" or "other".
+	Tag string `json:".tag"`
+}
+
+var sharedLinkSettingsErrorUnion = newUnion("other", map[string]interface{}{
+	"invalid_settings": nil,
+	"not_authorized":   nil,
+	"other":            nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SharedLinkSettingsError) UnmarshalJSON(b []byte) error {
+	return sharedLinkSettingsErrorUnion.unmarshal(b, e)
+}
+
+func (e *SharedLinkSettingsError) Error() string {
+	return e.Tag
+}
+
+// CreateSharedLinkError describes why a shared link could not be created.
+type CreateSharedLinkError struct {
+	// One of "path", "email_not_verified", "shared_link_already_exists",
+	// "settings_error", "access_denied" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *LookupError `json:"path,omitempty"`
+
+	// Set when Tag is "settings_error".
+	SettingsError *SharedLinkSettingsError `json:"settings_error,omitempty"`
+
+	// The existing link, if reported when Tag is
+	// "shared_link_already_exists".
+	ExistingLink SharedLinkMetadata `json:"-"`
+}
+
+var createSharedLinkErrorUnion = newUnion("other", map[string]interface{}{
+	"path":                       LookupError{},
+	"email_not_verified":         nil,
+	"shared_link_already_exists": nil,
+	"settings_error":             SharedLinkSettingsError{},
+	"access_denied":              nil,
+	"other":                      nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *CreateSharedLinkError) UnmarshalJSON(b []byte) error {
+	if err := createSharedLinkErrorUnion.unmarshal(b, e); err != nil {
+		return err
+	}
+	if e.Tag != "shared_link_already_exists" {
+		return nil
+	}
+	var v struct {
+		Exists *sharedLinkAlreadyExists `json:"shared_link_already_exists"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v.Exists != nil && v.Exists.Metadata != nil {
+		e.ExistingLink = v.Exists.Metadata.SharedLinkMetadata
+	}
+	return nil
+}
+
+// Unwrap returns the lookup or settings error, if any.
+func (e *CreateSharedLinkError) Unwrap() error {
+	switch {
+	case e.Path != nil:
+		return e.Path
+	case e.SettingsError != nil:
+		return e.SettingsError
+	}
+	return nil
+}
+
+func (e *CreateSharedLinkError) Error() string {
+	if err := e.Unwrap(); err != nil {
+		return "create shared link failed: " + e.Tag + "/" + err.Error()
+	}
+	return "create shared link failed: " + e.Tag
+}
+
+// sharedLinkAlreadyExists holds the link reported by a
+// "shared_link_already_exists" error.
+type sharedLinkAlreadyExists struct {
+	// Either "metadata" or "other".
+	Tag      string                   `json:".tag"`
+	Metadata *sharedLinkMetadataValue `json:"metadata"`
+}
+
+var sharedLinkAlreadyExistsUnion = newUnion("other", map[string]interface{}{
+	"metadata": sharedLinkMetadataValue{},
+	"other":    nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *sharedLinkAlreadyExists) UnmarshalJSON(b []byte) error {
+	return sharedLinkAlreadyExistsUnion.unmarshal(b, m)
+}
+
+// CreateSharedLinkWithSettings creates a shared link to the file or folder at path,
+// with the settings in settings, if not nil. If the path already has a
+// shared link, the existing link is returned instead. Errors are returned as
+// an *APIError holding a *CreateSharedLinkError.
+func (s *SharingService) CreateSharedLinkWithSettings(ctx context.Context, path string, settings *SharedLinkSettings) (SharedLinkMetadata, *http.Response, error) {
+	arg := struct {
+		Path     string              `json:"path"`
+		Settings *SharedLinkSettings `json:"settings,omitempty"`
+	}{path, settings}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/create_shared_link_with_settings", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var link sharedLinkMetadataValue
+	resp, err := s.client.do(ctx, (*http.Request)(req), &link, new(CreateSharedLinkError))
+	if err != nil {
+		return s.existingSharedLink(ctx, path, resp, err)
+	}
+
+	return link.SharedLinkMetadata, resp, nil
+}
+
+// existingSharedLink returns the link reported by err, if it is a
+// "shared_link_already_exists" error, or the first link listed for path.
+// Any other error is returned as is.
+func (s *SharingService) existingSharedLink(ctx context.Context, path string, resp *http.Response, err error) (SharedLinkMetadata, *http.Response, error) {
+	var createErr *CreateSharedLinkError
+	if !errors.As(err, &createErr) || createErr.Tag != "shared_link_already_exists" {
+		return nil, resp, err
+	}
+	if createErr.ExistingLink != nil {
+		return createErr.ExistingLink, resp, nil
+	}
+
+	it := s.ListSharedLinks(ctx, &ListSharedLinksOptions{Path: path, DirectOnly: true})
+	if it.Next() {
+		return it.Link(), it.Response(), nil
+	}
+	if it.Err() != nil {
+		return nil, it.Response(), it.Err()
+	}
+	return nil, resp, err
+}
+
+// ListSharedLinksOptions contains the options used to list shared links.
+type ListSharedLinksOptions struct {
+	// If not empty, only the links to this file or folder, or to its parent
+	// folders, are listed.
+	Path string `json:"path,omitempty"`
+
+	// The cursor returned by ListSharedLinksIterator.Cursor, to resume the
+	// listing.
+	Cursor string `json:"cursor,omitempty"`
+
+	// If true, only the links to Path itself are listed.
+	DirectOnly bool `json:"direct_only,omitempty"`
+}
+
+// ListSharedLinksError describes why the shared links could not be listed.
+type ListSharedLinksError struct {
+	// One of "path", "reset" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "path".
+	Path *LookupError `json:"path,omitempty"`
+}
+
+var listSharedLinksErrorUnion = newUnion("other", map[string]interface{}{
+	"path":  LookupError{},
+	"reset": nil,
+	"other": nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *ListSharedLinksError) UnmarshalJSON(b []byte) error {
+	return listSharedLinksErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the lookup error, if any.
+func (e *ListSharedLinksError) Unwrap() error {
+	if e.Path == nil {
+		return nil
+	}
+	return e.Path
+}
+
+func (e *ListSharedLinksError) Error() string {
+	if e.Tag == "path" && e.Path != nil {
+		return "list shared links failed: path/" + e.Path.Error()
+	}
+	return "list shared links failed: " + e.Tag
+}
+
+// ListSharedLinksIterator iterates over shared links, fetching them page by
+// page as needed. Use it as follows:
+//
+//	it := c.Sharing.ListSharedLinks(ctx, nil)
+//	for it.Next() {
+//		link := it.Link()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ListSharedLinksIterator struct {
+	ctx     context.Context
+	s       *SharingService
+	opts    ListSharedLinksOptions
+	hasMore bool
+	links   []SharedLinkMetadata
+	current SharedLinkMetadata
+	resp    *http.Response
+	err     error
+}
+
+// ListSharedLinks returns an iterator over the shared links of the user,
+// listed using the options in opts, if not nil. Errors are returned as an
+// *APIError holding a *ListSharedLinksError.
+func (s *SharingService) ListSharedLinks(ctx context.Context, opts *ListSharedLinksOptions) *ListSharedLinksIterator {
+	it := &ListSharedLinksIterator{ctx: ctx, s: s, hasMore: true}
+	if opts != nil {
+		it.opts = *opts
+	}
+	return it
+}
+
+// Next advances the iterator to the next link, which is then available
+// through the Link method. It returns false when there are no more links or
+// an error happens.
+func (it *ListSharedLinksIterator) Next() bool {
+	for len(it.links) == 0 {
+		if it.err != nil || !it.hasMore {
+			it.current = nil
+			return false
+		}
+		it.fetch()
+	}
+	it.current, it.links = it.links[0], it.links[1:]
+	return true
+}
+
+// Link returns the current link.
+func (it *ListSharedLinksIterator) Link() SharedLinkMetadata {
+	return it.current
+}
+
+// Cursor returns the cursor of the last page fetched, which can be used to
+// resume the listing using ListSharedLinksOptions.Cursor.
+func (it *ListSharedLinksIterator) Cursor() string {
+	return it.opts.Cursor
+}
+
+// Err returns the error, if any, that happened while iterating.
+func (it *ListSharedLinksIterator) Err() error {
+	return it.err
+}
+
+// Response returns the HTTP response of the last page fetched.
+func (it *ListSharedLinksIterator) Response() *http.Response {
+	return it.resp
+}
+
+func (it *ListSharedLinksIterator) fetch() {
+	var req *RPCRequest
+	req, it.err = it.s.client.NewRPCRequest("POST", "2-beta/sharing/list_shared_links", &it.opts)
+	if it.err != nil {
+		return
+	}
+	markIdempotent((*http.Request)(req))
+
+	var result struct {
+		Links   []sharedLinkMetadataValue `json:"links"`
+		HasMore bool                      `json:"has_more"`
+		Cursor  string                    `json:"cursor"`
+	}
+	it.resp, it.err = it.s.client.do(it.ctx, (*http.Request)(req), &result, new(ListSharedLinksError))
+	if it.err != nil {
+		return
+	}
+	it.links = make([]SharedLinkMetadata, len(result.Links))
+	for i, link := range result.Links {
+		it.links[i] = link.SharedLinkMetadata
+	}
+	it.opts.Cursor = result.Cursor
+	it.hasMore = result.HasMore && result.Cursor != ""
+}
+
+// SharedLinkError describes why a shared link could not be used, modified
+// or revoked.
+type SharedLinkError struct {
+	// One of "shared_link_not_found", "shared_link_access_denied",
+	// "unsupported_link_type", "shared_link_malformed",
+	// "shared_link_is_directory", "settings_error", "email_not_verified" or
+	// "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "settings_error".
+	SettingsError *SharedLinkSettingsError `json:"settings_error,omitempty"`
+}
+
+var sharedLinkErrorUnion = newUnion("other", map[string]interface{}{
+	"shared_link_not_found":     nil,
+	"shared_link_access_denied": nil,
+	"unsupported_link_type":     nil,
+	"shared_link_malformed":     nil,
+	"shared_link_is_directory":  nil,
+	"settings_error":            SharedLinkSettingsError{},
+	"email_not_verified":        nil,
+	"other":                     nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SharedLinkError) UnmarshalJSON(b []byte) error {
+	return sharedLinkErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the settings error, if any.
+func (e *SharedLinkError) Unwrap() error {
+	if e.SettingsError == nil {
+		return nil
+	}
+	return e.SettingsError
+}
+
+func (e *SharedLinkError) Error() string {
+	if e.SettingsError != nil {
+		return "shared link failed: settings_error/" + e.SettingsError.Error()
+	}
+	return "shared link failed: " + e.Tag
+}
+
+// ModifySharedLinkSettings changes the settings of the shared link url. If
+// removeExpiration is true, the expiration of the link is removed. The
+// updated link is returned. Errors are returned as an *APIError holding a
+// *SharedLinkError.
+func (s *SharingService) ModifySharedLinkSettings(ctx context.Context, url string, settings *SharedLinkSettings, removeExpiration bool) (SharedLinkMetadata, *http.Response, error) {
+	if settings == nil {
+		settings = &SharedLinkSettings{}
+	}
+	arg := struct {
+		URL              string              `json:"url"`
+		Settings         *SharedLinkSettings `json:"settings"`
+		RemoveExpiration bool                `json:"remove_expiration"`
+	}{url, settings, removeExpiration}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/modify_shared_link_settings", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var link sharedLinkMetadataValue
+	resp, err := s.client.do(ctx, (*http.Request)(req), &link, new(SharedLinkError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return link.SharedLinkMetadata, resp, nil
+}
+
+// RevokeSharedLink revokes the shared link url. Errors are returned as an
+// *APIError holding a *SharedLinkError.
+func (s *SharingService) RevokeSharedLink(ctx context.Context, url string) (*http.Response, error) {
+	arg := struct {
+		URL string `json:"url"`
+	}{url}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/revoke_shared_link", &arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.do(ctx, (*http.Request)(req), nil, new(SharedLinkError))
+}
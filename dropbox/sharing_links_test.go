@@ -0,0 +1,182 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateSharedLinkWithSettings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/create_shared_link_with_settings", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"path":"/report.pdf","settings":{"requested_visibility":"password","link_password":"secret","audience":"public","access":"viewer","expires":"2015-05-12T15:50:38Z"}}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("create_shared_link_with_settings body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{".tag":"file","url":"https://www.dropbox.com/s/2sn712vy1ovegw8/report.pdf?dl=0","name":"report.pdf",`+
+			`"path_lower":"/report.pdf","rev":"1","size":7212,"link_permissions":{"resolved_visibility":{".tag":"password"},`+
+			`"can_revoke":true,"effective_audience":{".tag":"public"},"link_access_level":{".tag":"viewer"},"allow_download":true}}`)
+	})
+
+	expires := time.Date(2015, 5, 12, 17, 50, 38, 5, time.FixedZone("CEST", 2*60*60))
+	link, _, err := client.Sharing.CreateSharedLinkWithSettings(context.Background(), "/report.pdf", &SharedLinkSettings{
+		RequestedVisibility: "password",
+		LinkPassword:        "secret",
+		Expires:             expires,
+		Audience:            "public",
+		Access:              "viewer",
+	})
+	if err != nil {
+		t.Fatalf("CreateSharedLinkWithSettings returned unexpected error: %v", err)
+	}
+	file, ok := link.(*FileLinkMetadata)
+	if !ok {
+		t.Fatalf("CreateSharedLinkWithSettings returned %T, want *FileLinkMetadata", link)
+	}
+	if got, want := file.Size, uint64(7212); got != want {
+		t.Errorf("CreateSharedLinkWithSettings Size is %v, want %v", got, want)
+	}
+	want := &LinkPermissions{
+		ResolvedVisibility: "password",
+		CanRevoke:          true,
+		EffectiveAudience:  "public",
+		LinkAccessLevel:    "viewer",
+		AllowDownload:      true,
+	}
+	if got := file.LinkPermissions; got == nil || *got != *want {
+		t.Errorf("CreateSharedLinkWithSettings LinkPermissions is %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateSharedLinkWithSettings_alreadyExists(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/create_shared_link_with_settings", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"shared_link_already_exists/..","error":{".tag":"shared_link_already_exists",`+
+			`"shared_link_already_exists":{".tag":"metadata","metadata":{".tag":"folder","url":"https://db.tt/a","name":"a","path_lower":"/a"}}}}`)
+	})
+
+	link, _, err := client.Sharing.CreateSharedLinkWithSettings(context.Background(), "/a", nil)
+	if err != nil {
+		t.Fatalf("CreateSharedLinkWithSettings returned unexpected error: %v", err)
+	}
+	if got, want := link.GetURL(), "https://db.tt/a"; got != want {
+		t.Errorf("CreateSharedLinkWithSettings URL is %v, want %v", got, want)
+	}
+}
+
+func TestCreateSharedLinkWithSettings_alreadyExistsList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/create_shared_link_with_settings", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"shared_link_already_exists/..","error":{".tag":"shared_link_already_exists"}}`)
+	})
+	mux.HandleFunc("/2-beta/sharing/list_shared_links", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"path":"/a","direct_only":true}`; got != want {
+			t.Errorf("list_shared_links body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"links":[{".tag":"folder","url":"https://db.tt/a","name":"a","path_lower":"/a"}],"has_more":false}`)
+	})
+
+	link, _, err := client.Sharing.CreateSharedLinkWithSettings(context.Background(), "/a", nil)
+	if err != nil {
+		t.Fatalf("CreateSharedLinkWithSettings returned unexpected error: %v", err)
+	}
+	if got, want := link.GetURL(), "https://db.tt/a"; got != want {
+		t.Errorf("CreateSharedLinkWithSettings URL is %v, want %v", got, want)
+	}
+}
+
+func TestListSharedLinks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/list_shared_links", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		switch got := strings.TrimSpace(string(body)); got {
+		case `{}`:
+			fmt.Fprint(w, `{"links":[{".tag":"file","url":"https://db.tt/1","name":"1"}],"has_more":true,"cursor":"c1"}`)
+		case `{"cursor":"c1"}`:
+			fmt.Fprint(w, `{"links":[{".tag":"folder","url":"https://db.tt/2","name":"2"}],"has_more":false,"cursor":"c2"}`)
+		default:
+			t.Errorf("Unexpected list_shared_links body %v", got)
+		}
+	})
+
+	it := client.Sharing.ListSharedLinks(context.Background(), nil)
+	var urls []string
+	for it.Next() {
+		urls = append(urls, it.Link().GetURL())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("ListSharedLinks returned unexpected error: %v", err)
+	}
+	if got, want := strings.Join(urls, ","), "https://db.tt/1,https://db.tt/2"; got != want {
+		t.Errorf("ListSharedLinks links are %v, want %v", got, want)
+	}
+	if got, want := it.Cursor(), "c2"; got != want {
+		t.Errorf("ListSharedLinks Cursor is %v, want %v", got, want)
+	}
+}
+
+func TestModifySharedLinkSettings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/modify_shared_link_settings", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"url":"https://db.tt/a","settings":{"allow_download":false},"remove_expiration":true}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("modify_shared_link_settings body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{".tag":"folder","url":"https://db.tt/a","name":"a","link_permissions":{"can_revoke":true,"allow_download":false}}`)
+	})
+
+	link, _, err := client.Sharing.ModifySharedLinkSettings(context.Background(), "https://db.tt/a", &SharedLinkSettings{AllowDownload: Bool(false)}, true)
+	if err != nil {
+		t.Fatalf("ModifySharedLinkSettings returned unexpected error: %v", err)
+	}
+	if _, ok := link.(*FolderLinkMetadata); !ok {
+		t.Errorf("ModifySharedLinkSettings returned %T, want *FolderLinkMetadata", link)
+	}
+}
+
+func TestRevokeSharedLink_notFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/revoke_shared_link", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"shared_link_not_found/..","error":{".tag":"shared_link_not_found"}}`)
+	})
+
+	_, err := client.Sharing.RevokeSharedLink(context.Background(), "https://db.tt/a")
+	var linkErr *SharedLinkError
+	if !errors.As(err, &linkErr) || linkErr.Tag != "shared_link_not_found" {
+		t.Errorf("RevokeSharedLink error is %#v, want a shared_link_not_found *SharedLinkError", err)
+	}
+}
@@ -0,0 +1,684 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// AccessLevel is the access level of a member on a shared folder or file.
+type AccessLevel string
+
+// Access levels of shared folder and file members.
+const (
+	// The member is the owner of the shared content.
+	AccessOwner AccessLevel = "owner"
+
+	// The member can add, edit and delete content.
+	AccessEditor AccessLevel = "editor"
+
+	// The member can view and comment on the content.
+	AccessViewer AccessLevel = "viewer"
+
+	// The member can only view the content.
+	AccessViewerNoComment AccessLevel = "viewer_no_comment"
+)
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *AccessLevel) UnmarshalJSON(b []byte) error {
+	var tag tagOnly
+	if err := tag.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	*l = AccessLevel(tag)
+	return nil
+}
+
+// MemberSelector selects a member of a shared folder or file, either by
+// email or by Dropbox ID.
+type MemberSelector struct {
+	// Either "email" or "dropbox_id".
+	Tag string `json:".tag"`
+
+	// The email address of the member. Set when Tag is "email".
+	Email string `json:"email,omitempty"`
+
+	// The Dropbox account, team member or group ID of the member. Set when
+	// Tag is "dropbox_id".
+	DropboxID string `json:"dropbox_id,omitempty"`
+}
+
+var memberSelectorUnion = newUnion("other", map[string]interface{}{
+	"email":      "",
+	"dropbox_id": "",
+	"other":      nil,
+})
+
+// MemberEmail returns a MemberSelector selecting the member with the given
+// email address.
+func MemberEmail(email string) MemberSelector {
+	return MemberSelector{Tag: "email", Email: email}
+}
+
+// MemberDropboxID returns a MemberSelector selecting the member with the
+// given Dropbox ID.
+func MemberDropboxID(id string) MemberSelector {
+	return MemberSelector{Tag: "dropbox_id", DropboxID: id}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *MemberSelector) UnmarshalJSON(b []byte) error {
+	return memberSelectorUnion.unmarshal(b, m)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m MemberSelector) MarshalJSON() ([]byte, error) {
+	return memberSelectorUnion.marshal(m)
+}
+
+// AddMember is a member added to a shared folder.
+type AddMember struct {
+	// The member to add.
+	Member MemberSelector `json:"member"`
+
+	// The access level granted to the member. Defaults to AccessViewer.
+	AccessLevel AccessLevel `json:"access_level,omitempty"`
+}
+
+// AddFolderMemberOptions contains the options used to add members to a
+// shared folder.
+type AddFolderMemberOptions struct {
+	// If true, the members are not notified by email.
+	Quiet bool `json:"quiet"`
+
+	// A message included in the invitation.
+	CustomMessage string `json:"custom_message,omitempty"`
+}
+
+// SharedFolderAccessError describes why a shared folder could not be
+// accessed.
+type SharedFolderAccessError struct {
+	// One of "invalid_id", "not_a_member", "email_unverified", "unmounted"
+	// or "other".
+	Tag string `json:".tag"`
+}
+
+var sharedFolderAccessErrorUnion = newUnion("other", map[string]interface{}{
+	"invalid_id":       nil,
+	"not_a_member":     nil,
+	"email_unverified": nil,
+	"unmounted":        nil,
+	"other":            nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SharedFolderAccessError) UnmarshalJSON(b []byte) error {
+	return sharedFolderAccessErrorUnion.unmarshal(b, e)
+}
+
+func (e *SharedFolderAccessError) Error() string {
+	return e.Tag
+}
+
+// AddMemberSelectorError describes why a member could not be added.
+type AddMemberSelectorError struct {
+	// One of "automatic_group", "invalid_dropbox_id", "invalid_email",
+	// "unverified_dropbox_id", "group_deleted", "group_not_on_team" or
+	// "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "invalid_dropbox_id".
+	InvalidDropboxID string `json:"invalid_dropbox_id,omitempty"`
+
+	// Set when Tag is "invalid_email".
+	InvalidEmail string `json:"invalid_email,omitempty"`
+
+	// Set when Tag is "unverified_dropbox_id".
+	UnverifiedDropboxID string `json:"unverified_dropbox_id,omitempty"`
+}
+
+var addMemberSelectorErrorUnion = newUnion("other", map[string]interface{}{
+	"automatic_group":       nil,
+	"invalid_dropbox_id":    "",
+	"invalid_email":         "",
+	"unverified_dropbox_id": "",
+	"group_deleted":         nil,
+	"group_not_on_team":     nil,
+	"other":                 nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *AddMemberSelectorError) UnmarshalJSON(b []byte) error {
+	return addMemberSelectorErrorUnion.unmarshal(b, e)
+}
+
+func (e *AddMemberSelectorError) Error() string {
+	switch {
+	case e.InvalidDropboxID != "":
+		return e.Tag + ": " + e.InvalidDropboxID
+	case e.InvalidEmail != "":
+		return e.Tag + ": " + e.InvalidEmail
+	case e.UnverifiedDropboxID != "":
+		return e.Tag + ": " + e.UnverifiedDropboxID
+	}
+	return e.Tag
+}
+
+// AddFolderMemberError describes why members could not be added to a
+// shared folder.
+type AddFolderMemberError struct {
+	// One of "access_error", "email_unverified", "banned_member",
+	// "bad_member", "cant_share_outside_team", "too_many_members",
+	// "too_many_pending_invites", "rate_limit", "too_many_invitees",
+	// "insufficient_plan", "team_folder", "no_permission",
+	// "invalid_shared_folder" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "access_error".
+	AccessError *SharedFolderAccessError `json:"access_error,omitempty"`
+
+	// Set when Tag is "bad_member".
+	BadMember *AddMemberSelectorError `json:"bad_member,omitempty"`
+
+	// The member limit of the folder. Set when Tag is "too_many_members".
+	TooManyMembers uint64 `json:"too_many_members,omitempty"`
+
+	// The pending invite limit of the folder. Set when Tag is
+	// "too_many_pending_invites".
+	TooManyPendingInvites uint64 `json:"too_many_pending_invites,omitempty"`
+}
+
+var addFolderMemberErrorUnion = newUnion("other", map[string]interface{}{
+	"access_error":             SharedFolderAccessError{},
+	"email_unverified":         nil,
+	"banned_member":            nil,
+	"bad_member":               AddMemberSelectorError{},
+	"cant_share_outside_team":  nil,
+	"too_many_members":         uint64(0),
+	"too_many_pending_invites": uint64(0),
+	"rate_limit":               nil,
+	"too_many_invitees":        nil,
+	"insufficient_plan":        nil,
+	"team_folder":              nil,
+	"no_permission":            nil,
+	"invalid_shared_folder":    nil,
+	"other":                    nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *AddFolderMemberError) UnmarshalJSON(b []byte) error {
+	return addFolderMemberErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the access or member error, if any.
+func (e *AddFolderMemberError) Unwrap() error {
+	switch {
+	case e.AccessError != nil:
+		return e.AccessError
+	case e.BadMember != nil:
+		return e.BadMember
+	}
+	return nil
+}
+
+func (e *AddFolderMemberError) Error() string {
+	switch {
+	case e.Unwrap() != nil:
+		return "add folder member failed: " + e.Tag + "/" + e.Unwrap().Error()
+	case e.TooManyMembers > 0:
+		return "add folder member failed: " + e.Tag + ": " + strconv.FormatUint(e.TooManyMembers, 10)
+	case e.TooManyPendingInvites > 0:
+		return "add folder member failed: " + e.Tag + ": " + strconv.FormatUint(e.TooManyPendingInvites, 10)
+	}
+	return "add folder member failed: " + e.Tag
+}
+
+// AddFolderMember adds members to the shared folder sharedFolderID, using
+// the options in opts, if not nil. Errors are returned as an *APIError
+// holding an *AddFolderMemberError.
+func (s *SharingService) AddFolderMember(ctx context.Context, sharedFolderID string, members []AddMember, opts *AddFolderMemberOptions) (*http.Response, error) {
+	arg := struct {
+		SharedFolderID string      `json:"shared_folder_id"`
+		Members        []AddMember `json:"members"`
+		AddFolderMemberOptions
+	}{SharedFolderID: sharedFolderID, Members: members}
+	if opts != nil {
+		arg.AddFolderMemberOptions = *opts
+	}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/add_folder_member", &arg)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.do(ctx, (*http.Request)(req), nil, new(AddFolderMemberError))
+}
+
+// MemberAccessLevelResult is the access level of a member after a change.
+type MemberAccessLevelResult struct {
+	// The access level of the member, if it still has explicit access.
+	AccessLevel AccessLevel `json:"access_level,omitempty"`
+
+	// A warning about the effect of the change, to be shown to the user.
+	Warning string `json:"warning,omitempty"`
+}
+
+// SharedFolderMemberError describes why a member of a shared folder could
+// not be selected.
+type SharedFolderMemberError struct {
+	// One of "invalid_dropbox_id", "not_a_member", "no_explicit_access" or
+	// "other".
+	Tag string `json:".tag"`
+
+	// The access the member has through its parent folders. Set when Tag is
+	// "no_explicit_access".
+	NoExplicitAccess *MemberAccessLevelResult `json:"no_explicit_access,omitempty"`
+}
+
+var sharedFolderMemberErrorUnion = newUnion("other", map[string]interface{}{
+	"invalid_dropbox_id": nil,
+	"not_a_member":       nil,
+	"no_explicit_access": MemberAccessLevelResult{},
+	"other":              nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *SharedFolderMemberError) UnmarshalJSON(b []byte) error {
+	return sharedFolderMemberErrorUnion.unmarshal(b, e)
+}
+
+func (e *SharedFolderMemberError) Error() string {
+	return e.Tag
+}
+
+// RemoveFolderMemberError describes why a member could not be removed from
+// a shared folder.
+type RemoveFolderMemberError struct {
+	// One of "access_error", "member_error", "folder_owner",
+	// "group_access", "team_folder", "no_permission", "too_many_files" or
+	// "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "access_error".
+	AccessError *SharedFolderAccessError `json:"access_error,omitempty"`
+
+	// Set when Tag is "member_error".
+	MemberError *SharedFolderMemberError `json:"member_error,omitempty"`
+}
+
+var removeFolderMemberErrorUnion = newUnion("other", map[string]interface{}{
+	"access_error":   SharedFolderAccessError{},
+	"member_error":   SharedFolderMemberError{},
+	"folder_owner":   nil,
+	"group_access":   nil,
+	"team_folder":    nil,
+	"no_permission":  nil,
+	"too_many_files": nil,
+	"other":          nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *RemoveFolderMemberError) UnmarshalJSON(b []byte) error {
+	return removeFolderMemberErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the access or member error, if any.
+func (e *RemoveFolderMemberError) Unwrap() error {
+	switch {
+	case e.AccessError != nil:
+		return e.AccessError
+	case e.MemberError != nil:
+		return e.MemberError
+	}
+	return nil
+}
+
+func (e *RemoveFolderMemberError) Error() string {
+	if err := e.Unwrap(); err != nil {
+		return "remove folder member failed: " + e.Tag + "/" + err.Error()
+	}
+	return "remove folder member failed: " + e.Tag
+}
+
+// removeMemberJobStatus is either the launch result of a member removal or
+// the result of checking its status.
+type removeMemberJobStatus struct {
+	// One of "async_job_id", "in_progress", "complete", "failed" or "other".
+	Tag        string                   `json:".tag"`
+	AsyncJobID string                   `json:"async_job_id"`
+	Complete   *MemberAccessLevelResult `json:"complete"`
+	Failed     *RemoveFolderMemberError `json:"failed"`
+}
+
+var removeMemberJobStatusUnion = newUnion("other", map[string]interface{}{
+	"async_job_id": "",
+	"in_progress":  nil,
+	"complete":     MemberAccessLevelResult{},
+	"failed":       RemoveFolderMemberError{},
+	"other":        nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *removeMemberJobStatus) UnmarshalJSON(b []byte) error {
+	return removeMemberJobStatusUnion.unmarshal(b, s)
+}
+
+func (s *removeMemberJobStatus) Pending() bool {
+	return s.Tag == "async_job_id" || s.Tag == "in_progress"
+}
+
+// RemoveFolderMember removes member from the shared folder sharedFolderID,
+// and waits for the removal to complete, checking its status as configured
+// by the PollPolicy of the client. If leaveACopy is true, the member keeps a
+// copy of the folder contents. The access the member keeps through its
+// parent folders, if any, is returned. Errors are returned as an *APIError
+// holding a *RemoveFolderMemberError, or as a *RemoveFolderMemberError if
+// the removal job fails.
+func (s *SharingService) RemoveFolderMember(ctx context.Context, sharedFolderID string, member MemberSelector, leaveACopy bool) (*MemberAccessLevelResult, *http.Response, error) {
+	arg := struct {
+		SharedFolderID string         `json:"shared_folder_id"`
+		Member         MemberSelector `json:"member"`
+		LeaveACopy     bool           `json:"leave_a_copy"`
+	}{sharedFolderID, member, leaveACopy}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/remove_folder_member", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var status removeMemberJobStatus
+	resp, err := s.client.do(ctx, (*http.Request)(req), &status, new(RemoveFolderMemberError))
+	if err != nil {
+		return nil, resp, err
+	}
+	checkResp, err := s.client.WaitAsyncJob(ctx, "2-beta/sharing/check_remove_member_job_status", status.AsyncJobID, &status, nil)
+	if checkResp != nil {
+		resp = checkResp
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+
+	switch {
+	case status.Complete != nil:
+		return status.Complete, resp, nil
+	case status.Failed != nil:
+		return nil, resp, status.Failed
+	}
+	return nil, resp, &RemoveFolderMemberError{Tag: status.Tag}
+}
+
+// UpdateFolderMemberError describes why the access level of a member of a
+// shared folder could not be changed.
+type UpdateFolderMemberError struct {
+	// One of "access_error", "member_error", "no_explicit_access",
+	// "insufficient_plan", "no_permission" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "access_error".
+	AccessError *SharedFolderAccessError `json:"access_error,omitempty"`
+
+	// Set when Tag is "member_error".
+	MemberError *SharedFolderMemberError `json:"member_error,omitempty"`
+
+	// Why the member could not be given explicit access. Set when Tag is
+	// "no_explicit_access".
+	NoExplicitAccess *AddFolderMemberError `json:"no_explicit_access,omitempty"`
+}
+
+var updateFolderMemberErrorUnion = newUnion("other", map[string]interface{}{
+	"access_error":       SharedFolderAccessError{},
+	"member_error":       SharedFolderMemberError{},
+	"no_explicit_access": AddFolderMemberError{},
+	"insufficient_plan":  nil,
+	"no_permission":      nil,
+	"other":              nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *UpdateFolderMemberError) UnmarshalJSON(b []byte) error {
+	return updateFolderMemberErrorUnion.unmarshal(b, e)
+}
+
+// Unwrap returns the access, member or add member error, if any.
+func (e *UpdateFolderMemberError) Unwrap() error {
+	switch {
+	case e.AccessError != nil:
+		return e.AccessError
+	case e.MemberError != nil:
+		return e.MemberError
+	case e.NoExplicitAccess != nil:
+		return e.NoExplicitAccess
+	}
+	return nil
+}
+
+func (e *UpdateFolderMemberError) Error() string {
+	if err := e.Unwrap(); err != nil {
+		return "update folder member failed: " + e.Tag + "/" + err.Error()
+	}
+	return "update folder member failed: " + e.Tag
+}
+
+// UpdateFolderMember changes the access level of member on the shared
+// folder sharedFolderID. Errors are returned as an *APIError holding an
+// *UpdateFolderMemberError.
+func (s *SharingService) UpdateFolderMember(ctx context.Context, sharedFolderID string, member MemberSelector, accessLevel AccessLevel) (*MemberAccessLevelResult, *http.Response, error) {
+	arg := struct {
+		SharedFolderID string         `json:"shared_folder_id"`
+		Member         MemberSelector `json:"member"`
+		AccessLevel    AccessLevel    `json:"access_level"`
+	}{sharedFolderID, member, accessLevel}
+	req, err := s.client.NewRPCRequest("POST", "2-beta/sharing/update_folder_member", &arg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(MemberAccessLevelResult)
+	resp, err := s.client.do(ctx, (*http.Request)(req), result, new(UpdateFolderMemberError))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result, resp, nil
+}
+
+// UserInfo contains information about a user member.
+type UserInfo struct {
+	// The account ID of the user.
+	AccountID string `json:"account_id"`
+
+	// The email address of the user.
+	Email string `json:"email"`
+
+	// The display name of the user.
+	DisplayName string `json:"display_name"`
+
+	// If the user is in the same team as the current user.
+	SameTeam bool `json:"same_team"`
+
+	// The team member ID of the user, if in the same team.
+	TeamMemberID string `json:"team_member_id,omitempty"`
+}
+
+// GroupInfo contains information about a group member.
+type GroupInfo struct {
+	// The name of the group.
+	GroupName string `json:"group_name"`
+
+	// The ID of the group.
+	GroupID string `json:"group_id"`
+
+	// The number of members of the group.
+	MemberCount uint32 `json:"member_count,omitempty"`
+
+	// If the current user is a member of the group.
+	IsMember bool `json:"is_member"`
+
+	// If the current user is an owner of the group.
+	IsOwner bool `json:"is_owner"`
+
+	// If the group is owned by the current user's team.
+	SameTeam bool `json:"same_team"`
+}
+
+// InviteeInfo identifies an invited member.
+type InviteeInfo struct {
+	// Either "email" or "other".
+	Tag string `json:".tag"`
+
+	// The email address the invitation was sent to. Set when Tag is "email".
+	Email string `json:"email,omitempty"`
+}
+
+var inviteeInfoUnion = newUnion("other", map[string]interface{}{
+	"email": "",
+	"other": nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *InviteeInfo) UnmarshalJSON(b []byte) error {
+	return inviteeInfoUnion.unmarshal(b, i)
+}
+
+// UserMembershipInfo is a user member of a shared folder or file.
+type UserMembershipInfo struct {
+	// The access level of the user.
+	AccessType AccessLevel `json:"access_type"`
+
+	// If the user has access through a parent folder.
+	IsInherited bool `json:"is_inherited"`
+
+	// The user.
+	User UserInfo `json:"user"`
+}
+
+// GroupMembershipInfo is a group member of a shared folder or file.
+type GroupMembershipInfo struct {
+	// The access level of the group.
+	AccessType AccessLevel `json:"access_type"`
+
+	// If the group has access through a parent folder.
+	IsInherited bool `json:"is_inherited"`
+
+	// The group.
+	Group GroupInfo `json:"group"`
+}
+
+// InviteeMembershipInfo is an invited member of a shared folder or file,
+// who has not joined yet.
+type InviteeMembershipInfo struct {
+	// The access level granted to the invitee.
+	AccessType AccessLevel `json:"access_type"`
+
+	// If the invitee has access through a parent folder.
+	IsInherited bool `json:"is_inherited"`
+
+	// The invitee.
+	Invitee InviteeInfo `json:"invitee"`
+
+	// The user the invitation was sent to, if it has an account.
+	User *UserInfo `json:"user,omitempty"`
+}
+
+// SharedMembers is a page of the members of a shared folder or file.
+type SharedMembers struct {
+	// The user members.
+	Users []UserMembershipInfo `json:"users"`
+
+	// The group members.
+	Groups []GroupMembershipInfo `json:"groups"`
+
+	// The invited members.
+	Invitees []InviteeMembershipInfo `json:"invitees"`
+
+	// The cursor used to get the next page of members, if any.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ListFolderMembersError describes why the members of a shared folder could
+// not be listed.
+type ListFolderMembersError struct {
+	// One of "access_error", "invalid_cursor" or "other".
+	Tag string `json:".tag"`
+
+	// Set when Tag is "access_error".
+	AccessError *SharedFolderAccessError `json:"access_error,omitempty"`
+}
+
+var listFolderMembersErrorUnion = newUnion("other", map[string]interface{}{
+	"access_error":   SharedFolderAccessError{},
+	"invalid_cursor": nil,
+	"other":          nil,
+})
+
+// UnmarshalJSON implements json.Unmarshaler. The error of list_folder_members
+// is a bare SharedFolderAccessError, which is reported as an "access_error".
+func (e *ListFolderMembersError) UnmarshalJSON(b []byte) error {
+	if err := listFolderMembersErrorUnion.unmarshal(b, e); err != nil {
+		return err
+	}
+	if e.Tag == "other" {
+		access := new(SharedFolderAccessError)
+		if err := access.UnmarshalJSON(b); err == nil && access.Tag != "other" {
+			*e = ListFolderMembersError{Tag: "access_error", AccessError: access}
+		}
+	}
+	return nil
+}
+
+// Unwrap returns the access error, if any.
+func (e *ListFolderMembersError) Unwrap() error {
+	if e.AccessError == nil {
+		return nil
+	}
+	return e.AccessError
+}
+
+func (e *ListFolderMembersError) Error() string {
+	if e.AccessError != nil {
+		return "list folder members failed: access_error/" + e.AccessError.Error()
+	}
+	return "list folder members failed: " + e.Tag
+}
+
+// ListFolderMembers returns the first page of the members of the shared
+// folder sharedFolderID, with up to limit members of each kind, or the
+// server default if zero. The next pages are got with
+// ListFolderMembersContinue. Errors are returned as an *APIError holding a
+// *ListFolderMembersError.
+func (s *SharingService) ListFolderMembers(ctx context.Context, sharedFolderID string, limit uint32) (*SharedMembers, *http.Response, error) {
+	arg := struct {
+		SharedFolderID string `json:"shared_folder_id"`
+		Limit          uint32 `json:"limit,omitempty"`
+	}{sharedFolderID, limit}
+	return s.listMembers(ctx, "2-beta/sharing/list_folder_members", &arg, new(ListFolderMembersError))
+}
+
+// ListFolderMembersContinue returns the page of shared folder members
+// following cursor. Errors are returned as an *APIError holding a
+// *ListFolderMembersError.
+func (s *SharingService) ListFolderMembersContinue(ctx context.Context, cursor string) (*SharedMembers, *http.Response, error) {
+	arg := struct {
+		Cursor string `json:"cursor"`
+	}{cursor}
+	return s.listMembers(ctx, "2-beta/sharing/list_folder_members/continue", &arg, new(ListFolderMembersError))
+}
+
+func (s *SharingService) listMembers(ctx context.Context, urlStr string, arg interface{}, apiErr error) (*SharedMembers, *http.Response, error) {
+	req, err := s.client.NewRPCRequest("POST", urlStr, arg)
+	if err != nil {
+		return nil, nil, err
+	}
+	markIdempotent((*http.Request)(req))
+
+	members := new(SharedMembers)
+	resp, err := s.client.do(ctx, (*http.Request)(req), members, apiErr)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return members, resp, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAddFolderMember(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/add_folder_member", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"shared_folder_id":"84528192421","members":[{"member":{".tag":"email","email":"justin@example.com"},"access_level":"editor"},` +
+			`{"member":{".tag":"dropbox_id","dropbox_id":"dbid:AAH4f99T0taONIb-OurWxbNQ6ywGRopQngc"}}],"quiet":true,"custom_message":"hi"}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("add_folder_member body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `null`)
+	})
+
+	members := []AddMember{
+		{Member: MemberEmail("justin@example.com"), AccessLevel: AccessEditor},
+		{Member: MemberDropboxID("dbid:AAH4f99T0taONIb-OurWxbNQ6ywGRopQngc")},
+	}
+	if _, err := client.Sharing.AddFolderMember(context.Background(), "84528192421", members, &AddFolderMemberOptions{Quiet: true, CustomMessage: "hi"}); err != nil {
+		t.Errorf("AddFolderMember returned unexpected error: %v", err)
+	}
+}
+
+func TestAddFolderMember_badMember(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/add_folder_member", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"bad_member/invalid_email/..","error":{".tag":"bad_member","bad_member":{".tag":"invalid_email","invalid_email":"x"}}}`)
+	})
+
+	_, err := client.Sharing.AddFolderMember(context.Background(), "84528192421", []AddMember{{Member: MemberEmail("x")}}, nil)
+	var memberErr *AddMemberSelectorError
+	if !errors.As(err, &memberErr) || memberErr.Tag != "invalid_email" {
+		t.Errorf("AddFolderMember returned error %#v, want an invalid_email *AddMemberSelectorError", err)
+	}
+}
+
+func TestRemoveFolderMember(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/remove_folder_member", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"shared_folder_id":"84528192421","member":{".tag":"email","email":"justin@example.com"},"leave_a_copy":false}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("remove_folder_member body is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{".tag":"async_job_id","async_job_id":"job"}`)
+	})
+	mux.HandleFunc("/2-beta/sharing/check_remove_member_job_status", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{".tag":"complete","access_level":{".tag":"viewer"},"warning":"inherited"}`)
+	})
+
+	result, _, err := client.Sharing.RemoveFolderMember(context.Background(), "84528192421", MemberEmail("justin@example.com"), false)
+	if err != nil {
+		t.Fatalf("RemoveFolderMember returned unexpected error: %v", err)
+	}
+	if got, want := result.AccessLevel, AccessViewer; got != want {
+		t.Errorf("RemoveFolderMember AccessLevel is %v, want %v", got, want)
+	}
+}
+
+func TestUpdateFolderMember(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/update_folder_member", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		want := `{"shared_folder_id":"84528192421","member":{".tag":"dropbox_id","dropbox_id":"dbid:a"},"access_level":"viewer"}`
+		if got := strings.TrimSpace(string(body)); got != want {
+			t.Errorf("update_folder_member body is %v, want %v", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"member_error/no_explicit_access/..","error":{".tag":"member_error","member_error":{".tag":"no_explicit_access"}}}`)
+	})
+
+	_, _, err := client.Sharing.UpdateFolderMember(context.Background(), "84528192421", MemberDropboxID("dbid:a"), AccessViewer)
+	var memberErr *SharedFolderMemberError
+	if !errors.As(err, &memberErr) || memberErr.Tag != "no_explicit_access" {
+		t.Errorf("UpdateFolderMember returned error %#v, want a no_explicit_access *SharedFolderMemberError", err)
+	}
+}
+
+func TestListFolderMembers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/list_folder_members", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"shared_folder_id":"84528192421","limit":10}`; got != want {
+			t.Errorf("list_folder_members body is %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"users":[{"access_type":{".tag":"owner"},"user":{"account_id":"dbid:a","same_team":true}}],`+
+			`"groups":[],"invitees":[{"access_type":{".tag":"viewer"},"invitee":{".tag":"email","email":"jessica@example.com"}}],"cursor":"c1"}`)
+	})
+
+	members, _, err := client.Sharing.ListFolderMembers(context.Background(), "84528192421", 10)
+	if err != nil {
+		t.Fatalf("ListFolderMembers returned unexpected error: %v", err)
+	}
+	if len(members.Users) != 1 || members.Users[0].AccessType != AccessOwner || members.Users[0].User.AccountID != "dbid:a" {
+		t.Errorf("ListFolderMembers users are %+v, want the owner dbid:a", members.Users)
+	}
+	if len(members.Invitees) != 1 || members.Invitees[0].Invitee.Email != "jessica@example.com" {
+		t.Errorf("ListFolderMembers invitees are %+v, want jessica@example.com", members.Invitees)
+	}
+	if got, want := members.Cursor, "c1"; got != want {
+		t.Errorf("ListFolderMembers Cursor is %v, want %v", got, want)
+	}
+}
+
+func TestListFolderMembers_accessError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2-beta/sharing/list_folder_members", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(409)
+		fmt.Fprint(w, `{"error_summary":"invalid_id/..","error":{".tag":"invalid_id"}}`)
+	})
+
+	_, _, err := client.Sharing.ListFolderMembers(context.Background(), "x", 0)
+	var listErr *ListFolderMembersError
+	if !errors.As(err, &listErr) || listErr.Tag != "access_error" {
+		t.Fatalf("ListFolderMembers returned error %#v, want an access_error *ListFolderMembersError", err)
+	}
+	if listErr.AccessError == nil || listErr.AccessError.Tag != "invalid_id" {
+		t.Errorf("ListFolderMembersError.AccessError is %#v, want invalid_id", listErr.AccessError)
+	}
+}
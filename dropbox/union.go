@@ -0,0 +1,201 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// tagField is the JSON field that holds the tag of a union.
+const tagField = ".tag"
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// union is a JSON codec for Dropbox API tagged unions. A union value is a
+// JSON object whose ".tag" field selects one of the union variants:
+//
+//	{".tag": "void_variant"}
+//	{".tag": "struct_variant", "field": 1, "other_field": 2}
+//	{".tag": "any_other_variant", "any_other_variant": value}
+//
+// Struct variants are inlined next to the tag, while any other value,
+// including nested unions, is stored in a field named as the tag. Void tags
+// may also be encoded as a plain JSON string.
+//
+// Union values are represented in Go either as a struct with a Tag field,
+// whose JSON name is ".tag", and a field for each non void variant, named as
+// the tag in its JSON field tag (see unmarshal and marshal), or as an
+// interface implemented by the variant types (see decode and encode).
+type union struct {
+	// Go type of each variant value, by tag. Void tags have a nil type.
+	variants map[string]reflect.Type
+
+	// The tag reported when an unknown tag is decoded. If empty, unknown
+	// tags are an error.
+	fallback string
+}
+
+// newUnion returns a new union codec. Variants maps each tag to a value of
+// the type of its variant, or to nil for void tags.
+func newUnion(fallback string, variants map[string]interface{}) *union {
+	u := &union{
+		variants: make(map[string]reflect.Type, len(variants)),
+		fallback: fallback,
+	}
+	for tag, v := range variants {
+		var t reflect.Type
+		if v != nil {
+			t = reflect.TypeOf(v)
+			for t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+		}
+		u.variants[tag] = t
+	}
+	return u
+}
+
+// inlined reports if values of type t are inlined next to the tag.
+func inlined(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !reflect.PtrTo(t).Implements(unmarshalerType)
+}
+
+// decode decodes a JSON encoded union. It returns the tag and a pointer to
+// the variant value, which is nil for void tags and for absent nullable
+// values.
+func (u *union) decode(data []byte) (string, interface{}, error) {
+	var tag string
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &tag); err != nil {
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return "", nil, err
+		}
+		if err := json.Unmarshal(fields[tagField], &tag); err != nil {
+			return "", nil, fmt.Errorf("dropbox: invalid union tag: %v", err)
+		}
+	}
+
+	t, ok := u.variants[tag]
+	if !ok {
+		if u.fallback == "" {
+			return "", nil, fmt.Errorf("dropbox: unknown union tag %q", tag)
+		}
+		return u.fallback, nil, nil
+	}
+	if t == nil {
+		return tag, nil, nil
+	}
+
+	raw := data
+	if !inlined(t) {
+		raw, ok = fields[tag]
+		if !ok || string(raw) == "null" {
+			return tag, nil, nil
+		}
+	}
+	v := reflect.New(t)
+	if err := json.Unmarshal(raw, v.Interface()); err != nil {
+		return "", nil, err
+	}
+	return tag, v.Interface(), nil
+}
+
+// encode JSON encodes a union with the given tag and variant value, which
+// must be nil for void tags.
+func (u *union) encode(tag string, v interface{}) ([]byte, error) {
+	blob, err := json.Marshal(tag)
+	if err != nil {
+		return nil, err
+	}
+	fields := []string{fmt.Sprintf("%q:%s", tagField, blob)}
+
+	if rv := reflect.ValueOf(v); v != nil && !(rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if inlined(reflect.Indirect(rv).Type()) {
+			value = bytes.TrimSpace(value)
+			if inner := bytes.TrimSpace(value[1 : len(value)-1]); len(inner) > 0 {
+				fields = append(fields, string(inner))
+			}
+		} else {
+			fields = append(fields, fmt.Sprintf("%s:%s", blob, value))
+		}
+	}
+	return []byte("{" + strings.Join(fields, ",") + "}"), nil
+}
+
+// unmarshal decodes a JSON encoded union in the struct pointed by dst.
+func (u *union) unmarshal(data []byte, dst interface{}) error {
+	tag, v, err := u.decode(data)
+	if err != nil {
+		return err
+	}
+
+	s := reflect.ValueOf(dst).Elem()
+	fields := unionFields(s.Type())
+	s.Set(reflect.Zero(s.Type()))
+	s.Field(fields[tagField]).SetString(tag)
+	i, ok := fields[tag]
+	if !ok || v == nil {
+		return nil
+	}
+	f, rv := s.Field(i), reflect.ValueOf(v)
+	if f.Kind() != reflect.Ptr {
+		rv = rv.Elem()
+	}
+	f.Set(rv)
+	return nil
+}
+
+// marshal JSON encodes the union struct src.
+func (u *union) marshal(src interface{}) ([]byte, error) {
+	s := reflect.Indirect(reflect.ValueOf(src))
+	fields := unionFields(s.Type())
+	tag := s.Field(fields[tagField]).String()
+	var v interface{}
+	if i, ok := fields[tag]; ok && u.variants[tag] != nil {
+		v = s.Field(i).Interface()
+	}
+	return u.encode(tag, v)
+}
+
+// unionFields returns the index of each field of the union struct type t,
+// by JSON name.
+func unionFields(t reflect.Type) map[string]int {
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = i
+		}
+	}
+	return fields
+}
+
+// tagOnly is the tag of a union whose variants are all void, decoded either
+// from a JSON object with a ".tag" field or from a plain JSON string.
+type tagOnly string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *tagOnly) UnmarshalJSON(b []byte) error {
+	var tag string
+	if err := json.Unmarshal(b, &tag); err != nil {
+		var fields struct {
+			Tag string `json:".tag"`
+		}
+		if err := json.Unmarshal(b, &fields); err != nil {
+			return err
+		}
+		tag = fields.Tag
+	}
+	*t = tagOnly(tag)
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2015, Álvaro Vilanova Vidal
+// Use of this source code is governed by a BSD 3-Clause license that can be
+// found in the LICENSE file.
+
+package dropbox
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type testStruct struct {
+	A string `json:"a"`
+	B int    `json:"b,omitempty"`
+}
+
+type testUnion struct {
+	Tag    string      `json:".tag"`
+	Struct *testStruct `json:"struct,omitempty"`
+	Nested *testUnion  `json:"nested,omitempty"`
+	Number int         `json:"number,omitempty"`
+}
+
+var testUnionCodec = newUnion("other", map[string]interface{}{
+	"void":   nil,
+	"struct": testStruct{},
+	"nested": testUnion{},
+	"number": 0,
+	"other":  nil,
+})
+
+func (u testUnion) MarshalJSON() ([]byte, error) {
+	return testUnionCodec.marshal(u)
+}
+
+func (u *testUnion) UnmarshalJSON(b []byte) error {
+	return testUnionCodec.unmarshal(b, u)
+}
+
+func TestUnion_unmarshal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want testUnion
+	}{
+		{`{".tag":"void"}`, testUnion{Tag: "void"}},
+		{`"void"`, testUnion{Tag: "void"}},
+		{`{".tag":"struct","a":"x","b":1}`, testUnion{Tag: "struct", Struct: &testStruct{"x", 1}}},
+		{`{".tag":"nested","nested":{".tag":"number","number":7}}`, testUnion{Tag: "nested", Nested: &testUnion{Tag: "number", Number: 7}}},
+		{`{".tag":"number","number":7}`, testUnion{Tag: "number", Number: 7}},
+		{`{".tag":"number"}`, testUnion{Tag: "number"}},
+		{`{".tag":"unknown","unknown":{"c":1}}`, testUnion{Tag: "other"}},
+	}
+	for _, test := range tests {
+		var got testUnion
+		if err := json.Unmarshal([]byte(test.in), &got); err != nil {
+			t.Errorf("json.Unmarshal(%s) returned unexpected error: %v", test.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("json.Unmarshal(%s) is %#v, want %#v", test.in, got, test.want)
+		}
+	}
+}
+
+func TestUnion_unmarshalErrors(t *testing.T) {
+	strict := newUnion("", map[string]interface{}{"void": nil})
+	for _, in := range []string{`{".tag":"unknown"}`, `{".tag":1}`, `[]`, `{`} {
+		var got testUnion
+		if err := strict.unmarshal([]byte(in), &got); err == nil {
+			t.Errorf("unmarshal(%s) expected error to be returned", in)
+		}
+	}
+}
+
+func TestUnion_marshal(t *testing.T) {
+	tests := []struct {
+		in   testUnion
+		want string
+	}{
+		{testUnion{Tag: "void"}, `{".tag":"void"}`},
+		{testUnion{Tag: "struct", Struct: &testStruct{A: "x"}}, `{".tag":"struct","a":"x"}`},
+		{testUnion{Tag: "nested", Nested: &testUnion{Tag: "void"}}, `{".tag":"nested","nested":{".tag":"void"}}`},
+		{testUnion{Tag: "number", Number: 7}, `{".tag":"number","number":7}`},
+	}
+	for _, test := range tests {
+		got, err := json.Marshal(test.in)
+		if err != nil {
+			t.Errorf("json.Marshal(%#v) returned unexpected error: %v", test.in, err)
+			continue
+		}
+		if string(got) != test.want {
+			t.Errorf("json.Marshal(%#v) is %s, want %s", test.in, got, test.want)
+		}
+	}
+}
+
+func TestUnion_decode(t *testing.T) {
+	tag, v, err := metadataUnion.decode([]byte(`{".tag":"folder","name":"B"}`))
+	if err != nil {
+		t.Fatalf("decode returned unexpected error: %v", err)
+	}
+	if got, want := tag, "folder"; got != want {
+		t.Errorf("decode tag is %v, want %v", got, want)
+	}
+	if got, want := v, (&FolderMetadata{Name: "B"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("decode value is %#v, want %#v", got, want)
+	}
+
+	blob, err := metadataUnion.encode("folder", v)
+	if err != nil {
+		t.Fatalf("encode returned unexpected error: %v", err)
+	}
+	if got, want := string(blob), `{".tag":"folder","name":"B","id":"","path_lower":"","path_display":""}`; got != want {
+		t.Errorf("encode is %s, want %s", got, want)
+	}
+}
+
+func TestTagOnly_unmarshal(t *testing.T) {
+	for _, in := range []string{`"viewer"`, `{".tag":"viewer"}`} {
+		var tag tagOnly
+		if err := json.Unmarshal([]byte(in), &tag); err != nil {
+			t.Errorf("json.Unmarshal(%s) returned unexpected error: %v", in, err)
+		}
+		if got, want := tag, tagOnly("viewer"); got != want {
+			t.Errorf("json.Unmarshal(%s) is %v, want %v", in, got, want)
+		}
+	}
+}
@@ -4,7 +4,10 @@
 
 package dropbox
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
 
 // AccountInfo contains a Dropbox user account information.
 type AccountInfo struct {
@@ -46,14 +49,15 @@ type Username struct {
 }
 
 // GetAccount retrieves information about the current user account.
-func (s *UsersService) GetAccount() (*AccountInfo, *http.Response, error) {
+func (s *UsersService) GetAccount(ctx context.Context) (*AccountInfo, *http.Response, error) {
 	req, err := s.client.NewRPCRequest("POST", "2-beta/users/get_current_account", nil)
 	if err != nil {
 		return nil, nil, err
 	}
+	markIdempotent((*http.Request)(req))
 
 	var info AccountInfo
-	resp, err := s.client.DoRPC(req, &info)
+	resp, err := s.client.DoRPC(ctx, req, &info)
 	if err != nil {
 		return nil, resp, err
 	}